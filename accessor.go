@@ -0,0 +1,65 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Accessor reads and writes the fields of a struct without reflect.  Implementations are
+// generated by the setgen command in cmd/setgen and registered with Mapper.RegisterAccessor;
+// Mapper.Bind then returns a BoundMapping that uses the Accessor before falling back to reflect.
+//
+// An Accessor is stateless.  The v argument to its methods is a pointer to the struct type the
+// Accessor was generated for and nil pointers between v and the field are instantiated.  Methods
+// return false when they do not handle key, in which case BoundMapping uses reflect as usual.
+type Accessor interface {
+	// Indeces returns the keys handled by the Accessor and the index of each field in the
+	// same form as Mapping.Indeces.
+	Indeces() map[string][]int
+
+	// Set assigns value to the field at key.  Set only handles value when it has exactly the
+	// type of a field whose type is a built-in scalar such as int or string.
+	Set(v interface{}, key string, value interface{}) bool
+
+	// Assignable returns a pointer to the field at key.
+	Assignable(v interface{}, key string) (interface{}, bool)
+
+	// Field returns the value of the field at key.
+	Field(v interface{}, key string) (interface{}, bool)
+}
+
+// RegisterAccessor registers accessor for the struct type of T; subsequent calls to Bind with
+// a T or pointer to T return a BoundMapping that uses accessor.  Registering a different
+// accessor for the same type replaces the previous one.
+//
+// The Accessor must have been generated with the same rules as this Mapper; an error wrapping
+// ErrAccessorMismatch is returned if one of its keys does not exist in the Mapping for T or
+// refers to a different field.
+func (me *Mapper) RegisterAccessor(T interface{}, accessor Accessor) error {
+	var typeInfo TypeInfo
+	switch tt := T.(type) {
+	case reflect.Type:
+		typeInfo = TypeCache.StatType(tt)
+	case reflect.Value:
+		typeInfo = TypeCache.StatType(tt.Type())
+	default:
+		typeInfo = TypeCache.Stat(T)
+	}
+	if !typeInfo.IsStruct {
+		return pkgerr{Err: ErrUnsupported, CallSite: "Mapper.RegisterAccessor", Context: "type " + typeInfo.Type.String() + " is not a struct"}
+	}
+	mapping := me.Map(typeInfo.Type)
+	for key, index := range accessor.Indeces() {
+		have, ok := mapping.Indeces[key]
+		if !ok || mapping.ReflectPaths[key].Elem != nil || !reflect.DeepEqual(have, index) {
+			return pkgerr{
+				Err:      ErrAccessorMismatch,
+				CallSite: "Mapper.RegisterAccessor",
+				Context:  fmt.Sprintf("key [%v] with index %v not found in type %v", key, index, typeInfo.Type),
+				Hint:     "generate the accessor with the same rules as the Mapper",
+			}
+		}
+	}
+	me.accessors.Store(typeInfo.Type, accessor)
+	return nil
+}
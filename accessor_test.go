@@ -0,0 +1,148 @@
+package set_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+type accessorT struct {
+	Name  string
+	Count int
+	Vec   [2]int
+}
+
+// accessorTAccessor is written in the same manner as the code generated by cmd/setgen; calls
+// counts the calls it handles.
+type accessorTAccessor struct {
+	indeces map[string][]int
+	calls   *int
+}
+
+func (a accessorTAccessor) Indeces() map[string][]int {
+	return a.indeces
+}
+
+func (a accessorTAccessor) Set(v interface{}, key string, value interface{}) bool {
+	x := v.(*accessorT)
+	switch key {
+	case "Name":
+		if tt, ok := value.(string); ok {
+			*a.calls++
+			x.Name = tt
+			return true
+		}
+	}
+	return false
+}
+
+func (a accessorTAccessor) Assignable(v interface{}, key string) (interface{}, bool) {
+	x := v.(*accessorT)
+	switch key {
+	case "Name":
+		*a.calls++
+		return &x.Name, true
+	}
+	return nil, false
+}
+
+func (a accessorTAccessor) Field(v interface{}, key string) (interface{}, bool) {
+	x := v.(*accessorT)
+	switch key {
+	case "Name":
+		*a.calls++
+		return x.Name, true
+	}
+	return nil, false
+}
+
+func TestMapper_RegisterAccessor(t *testing.T) {
+	t.Run("errors", func(t *testing.T) {
+		chk := assert.New(t)
+		mapper := &set.Mapper{Join: "_"}
+		var calls int
+		for _, indeces := range []map[string][]int{
+			{"Missing": {0}},
+			{"Name": {1}},
+			{"Vec_0": {2}},
+		} {
+			err := mapper.RegisterAccessor(accessorT{}, accessorTAccessor{indeces: indeces, calls: &calls})
+			chk.ErrorIs(err, set.ErrAccessorMismatch)
+		}
+		err := mapper.RegisterAccessor(0, accessorTAccessor{calls: &calls})
+		chk.ErrorIs(err, set.ErrUnsupported)
+		//
+		// Nothing was registered.
+		var dest accessorT
+		bound, err := mapper.Bind(&dest)
+		chk.NoError(err)
+		chk.NoError(bound.Set("Name", "reflect"))
+		chk.Equal(0, calls)
+	})
+	t.Run("bind", func(t *testing.T) {
+		chk := assert.New(t)
+		mapper := &set.Mapper{Join: "_"}
+		var calls int
+		chk.NoError(mapper.RegisterAccessor(&accessorT{}, accessorTAccessor{indeces: map[string][]int{"Name": {0}}, calls: &calls}))
+		//
+		var a, b accessorT
+		bound, err := mapper.Bind(&a)
+		chk.NoError(err)
+		chk.NoError(bound.Set("Name", "a"))
+		chk.NoError(bound.Set("Count", 1))
+		chk.NoError(bound.Set("Vec_1", 2))
+		chk.Equal(accessorT{Name: "a", Count: 1, Vec: [2]int{0, 2}}, a)
+		chk.Equal(1, calls)
+		//
+		bound.Rebind(&b)
+		chk.NoError(bound.Set("Name", 42)) // NB  Coerced by reflect.
+		chk.Equal("42", b.Name)
+		chk.Equal(1, calls)
+		fields, err := bound.Fields([]string{"Name", "Count"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"42", 0}, fields)
+		assignables, err := bound.Assignables([]string{"Name", "Count"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{&b.Name, &b.Count}, assignables)
+		chk.Equal(3, calls)
+		//
+		copied := bound.Copy()
+		copied.Rebind(&a)
+		chk.NoError(copied.Set("Name", "copied"))
+		chk.Equal("copied", a.Name)
+		chk.Equal("42", b.Name)
+		chk.Equal(4, calls)
+	})
+	t.Run("converters", func(t *testing.T) {
+		chk := assert.New(t)
+		registry := set.NewConverterRegistry()
+		registry.Register("", "", func(v interface{}) (interface{}, error) { return "converted " + v.(string), nil })
+		mapper := &set.Mapper{Converters: registry}
+		var calls int
+		chk.NoError(mapper.RegisterAccessor(accessorT{}, accessorTAccessor{indeces: map[string][]int{"Name": {0}}, calls: &calls}))
+		var dest accessorT
+		bound, err := mapper.Bind(&dest)
+		chk.NoError(err)
+		chk.NoError(bound.Set("Name", "a"))
+		chk.Equal("converted a", dest.Name)
+		chk.Equal(0, calls)
+	})
+	t.Run("sql adapters", func(t *testing.T) {
+		chk := assert.New(t)
+		mapper := &set.Mapper{SQLAdapters: true}
+		var calls int
+		chk.NoError(mapper.RegisterAccessor(accessorT{}, accessorTAccessor{indeces: map[string][]int{"Name": {0}}, calls: &calls}))
+		var dest accessorT
+		bound, err := mapper.Bind(&dest)
+		chk.NoError(err)
+		assignables, err := bound.Assignables([]string{"Name"}, nil)
+		chk.NoError(err)
+		chk.Implements((*sql.Scanner)(nil), assignables[0])
+		_, err = bound.Fields([]string{"Name"}, nil)
+		chk.NoError(err)
+		chk.Equal(0, calls)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"time"
+	"unsafe"
 
 	"github.com/nofeaturesonlybugs/set/path"
 )
@@ -36,8 +37,57 @@ type BoundMapping struct {
 	value reflect.Value
 	err   error
 
+	// readOnly is true when created by Mapper.BindReadOnly; value is then the bound value after
+	// following any non-nil pointers and err always wraps ErrReadOnly.
+	readOnly bool
+
 	// NB  This field should be treated as read-only.
 	paths map[string]path.ReflectPath
+
+	// resolver finds keys that are not exact matches for a key in paths; it is nil unless
+	// Mapper.IndexSlices is true or Mapper.Normalize is set.
+	resolver *keyResolver
+
+	// converters is the ConverterRegistry from the Mapper that created the BoundMapping.
+	// sqlAdapters is Mapper.SQLAdapters from the Mapper that created the BoundMapping.
+	converters  *ConverterRegistry
+	sqlAdapters bool
+
+	// accessor is the Accessor registered for the bound type, if any, and ptr is the pointer to
+	// value passed to its methods.
+	accessor Accessor
+	ptr      interface{}
+
+	// defaults and defaultKeys are Mapping.Defaults and the order to apply them; see ApplyDefaults.
+	// NB  These fields should be treated as read-only.
+	defaults    map[string]string
+	defaultKeys []string
+}
+
+// ApplyDefaults assigns the default value from the struct tag named by Mapper.DefaultTag to each
+// field of the bound struct that has one and whose value is zero.  Defaults are assigned with Set
+// so they are coerced with the same rules and nil pointers leading to the field are allocated.
+//
+// Call ApplyDefaults after filling the struct from other sources so only fields left untouched
+// receive their defaults.  Fields that are elements of slices do not have defaults.  If a default
+// can not be assigned the error wraps a *FieldError.
+func (b *BoundMapping) ApplyDefaults() error {
+	if b.err != nil && errors.Is(b.err, ErrReadOnly) {
+		return b.err.(pkgerr).WithCallSite("BoundMapping.ApplyDefaults")
+	}
+	for _, key := range b.defaultKeys {
+		value, ok := b.defaults[key]
+		if !ok {
+			continue
+		}
+		if v, ok := b.paths[key].Read(b.value); ok && !v.IsZero() {
+			continue
+		}
+		if err := b.Set(key, value); err != nil {
+			return err.(pkgerr).WithCallSite("BoundMapping.ApplyDefaults")
+		}
+	}
+	return nil
 }
 
 // Assignables returns a slice of pointers to the fields in the currently bound struct
@@ -51,6 +101,9 @@ type BoundMapping struct {
 //
 // An example use-case would be obtaining a slice of pointers for Rows.Scan() during database
 // query results.
+//
+// If the Mapper that created the BoundMapping has SQLAdapters enabled then the returned values
+// implement sql.Scanner instead of being pointers; see Mapper.SQLAdapters.
 func (b BoundMapping) Assignables(fields []string, rv []interface{}) ([]interface{}, error) {
 	if b.err != nil && errors.Is(b.err, ErrReadOnly) {
 		return rv, b.err.(pkgerr).WithCallSite("BoundMapping.Assignables")
@@ -59,14 +112,18 @@ func (b BoundMapping) Assignables(fields []string, rv []interface{}) ([]interfac
 		rv = make([]interface{}, len(fields))
 	}
 	for fieldN, name := range fields {
+		if b.accessor != nil && !b.sqlAdapters {
+			var ok bool
+			if rv[fieldN], ok = b.accessor.Assignable(b.ptr, name); ok {
+				continue
+			}
+		}
 		step, ok := b.paths[name]
 		if !ok {
-			err := pkgerr{
-				Err:      ErrUnknownField,
-				CallSite: "BoundMapping.Assignables",
-				Context:  "field [" + name + "] not found in type " + b.top.String(),
+			var err error
+			if step, err = b.resolver.lookup(b.paths, name, b.top, "BoundMapping.Assignables"); err != nil {
+				return rv, err
 			}
-			return rv, err
 		}
 		v := b.value
 		if step.HasPointer { // NB  Begin manual inline of path.ReflectPath.Value
@@ -84,6 +141,13 @@ func (b BoundMapping) Assignables(fields []string, rv []interface{}) ([]interfac
 			}
 		}
 		v = v.Field(step.Last) // NB  End manual inline of path.ReflectPath.Value
+		if step.Elem != nil {
+			v = step.Elem.Value(v)
+		}
+		if b.sqlAdapters {
+			rv[fieldN] = newFieldScanner(rv[fieldN], v, b.converters)
+			continue
+		}
 		rv[fieldN] = v.Addr().Interface()
 	}
 	return rv, nil
@@ -96,10 +160,18 @@ func (b BoundMapping) Assignables(fields []string, rv []interface{}) ([]interfac
 // it can be obtained by calling Copy on the cached BoundMapping for that type.
 func (b BoundMapping) Copy() BoundMapping {
 	return BoundMapping{
-		top:   b.top,
-		value: b.value,
-		err:   b.err,
-		paths: b.paths,
+		top:         b.top,
+		value:       b.value,
+		err:         b.err,
+		readOnly:    b.readOnly,
+		paths:       b.paths,
+		resolver:    b.resolver,
+		converters:  b.converters,
+		sqlAdapters: b.sqlAdapters,
+		accessor:    b.accessor,
+		ptr:         b.ptr,
+		defaults:    b.defaults,
+		defaultKeys: b.defaultKeys,
 	}
 }
 
@@ -116,12 +188,10 @@ func (b BoundMapping) Field(field string) (Value, error) {
 	}
 	step, ok := b.paths[field]
 	if !ok {
-		err := pkgerr{
-			Err:      ErrUnknownField,
-			CallSite: "BoundMapping.Field",
-			Context:  "field [" + field + "] not found in type " + b.top.String(),
+		var err error
+		if step, err = b.resolver.lookup(b.paths, field, b.top, "BoundMapping.Field"); err != nil {
+			return zeroV, err
 		}
-		return zeroV, err
 	}
 	v := b.value
 	if step.HasPointer { // NB  Begin manual inline of path.ReflectPath.Value
@@ -139,6 +209,9 @@ func (b BoundMapping) Field(field string) (Value, error) {
 		}
 	}
 	v = v.Field(step.Last) // NB  End manual inline of path.ReflectPath.Value
+	if step.Elem != nil {
+		v = step.Elem.Value(v)
+	}
 	//
 	return V(v), nil
 }
@@ -150,10 +223,14 @@ func (b BoundMapping) Field(field string) (Value, error) {
 // passed as the second argument to Fields.  If non-nil it is assumed len(fields) == len(rv)
 // and failure to provide an appropriately sized non-nil slice will cause a panic.
 //
-// During traversal this method will allocate struct fields that are nil pointers.
+// During traversal this method will allocate struct fields that are nil pointers; use
+// ReadFields to read without allocating.
 //
 // An example use-case would be obtaining a slice of query arguments by column name during
 // database queries.
+//
+// If the Mapper that created the BoundMapping has SQLAdapters enabled then the returned values
+// are suitable as database/sql query arguments; see Mapper.SQLAdapters.
 func (b BoundMapping) Fields(fields []string, rv []interface{}) ([]interface{}, error) {
 	if b.err != nil && errors.Is(b.err, ErrReadOnly) {
 		return rv, b.err.(pkgerr).WithCallSite("BoundMapping.Fields")
@@ -162,14 +239,22 @@ func (b BoundMapping) Fields(fields []string, rv []interface{}) ([]interface{},
 		rv = make([]interface{}, len(fields))
 	}
 	for fieldN, name := range fields {
+		if b.accessor != nil && !b.sqlAdapters {
+			var ok bool
+			if rv[fieldN], ok = b.accessor.Field(b.ptr, name); ok {
+				continue
+			}
+		}
 		step, ok := b.paths[name]
 		if !ok {
-			err := pkgerr{
-				Err:      ErrUnknownField,
-				CallSite: "BoundMapping.Fields",
-				Context:  "field [" + name + "] not found in type " + b.top.String(),
+			var err error
+			if step, err = b.resolver.lookup(b.paths, name, b.top, "BoundMapping.Fields"); err != nil {
+				return rv, err
 			}
-			return rv, err
+		}
+		if step.Offsets != nil && !b.sqlAdapters {
+			rv[fieldN] = unsafeRead(unsafe.Pointer(b.value.UnsafeAddr()), step.Offsets, true)
+			continue
 		}
 		v := b.value
 		if step.HasPointer { // NB  Begin manual inline of path.ReflectPath.Value
@@ -187,6 +272,16 @@ func (b BoundMapping) Fields(fields []string, rv []interface{}) ([]interface{},
 			}
 		}
 		v = v.Field(step.Last) // NB  End manual inline of path.ReflectPath.Value
+		if step.Elem != nil {
+			v = step.Elem.Value(v)
+		}
+		if b.sqlAdapters {
+			var err error
+			if rv[fieldN], err = driverValue(v); err != nil {
+				return rv, pkgerr{Err: err, CallSite: "BoundMapping.Fields", Context: "field [" + name + "] in type " + b.top.String()}
+			}
+			continue
+		}
 		// NB  The value we want is v.Interface() which performs a number of allocations for built-in primitives.
 		//     If we switch off v's type as a pointer and it is a primitive we can skip the allocations.
 		switch ptr := v.Addr().Interface().(type) {
@@ -227,6 +322,54 @@ func (b BoundMapping) Fields(fields []string, rv []interface{}) ([]interface{},
 	return rv, nil
 }
 
+// ReadFields is the read-only counterpart of Fields; it returns a slice of values of the fields
+// in the currently bound struct in the order specified by the fields argument.
+//
+// ReadFields never allocates nil pointers or otherwise modifies the bound struct.  If a nil
+// pointer is reached before a field, or the field is an element beyond the length of a slice,
+// then its value is nil.  When the BoundMapping was created by Mapper.BindReadOnly the bound
+// value does not need to be addressable.
+//
+// The rv argument and SQLAdapters behave the same as for Fields.
+func (b BoundMapping) ReadFields(fields []string, rv []interface{}) ([]interface{}, error) {
+	if !b.readOnly && b.err != nil && errors.Is(b.err, ErrReadOnly) {
+		return rv, b.err.(pkgerr).WithCallSite("BoundMapping.ReadFields")
+	}
+	if rv == nil {
+		rv = make([]interface{}, len(fields))
+	}
+	for fieldN, name := range fields {
+		step, ok := b.paths[name]
+		if !ok {
+			var err error
+			if step, err = b.resolver.lookup(b.paths, name, b.top, "BoundMapping.ReadFields"); err != nil {
+				return rv, err
+			}
+		}
+		if step.Offsets != nil && !b.sqlAdapters && b.value.Kind() == reflect.Struct && b.value.CanAddr() {
+			rv[fieldN] = unsafeRead(unsafe.Pointer(b.value.UnsafeAddr()), step.Offsets, false)
+			continue
+		}
+		var v reflect.Value
+		if ok = b.value.Kind() == reflect.Struct; ok {
+			v, ok = step.Read(b.value)
+		}
+		if !ok {
+			rv[fieldN] = nil
+			continue
+		}
+		if b.sqlAdapters {
+			var err error
+			if rv[fieldN], err = driverValue(v); err != nil {
+				return rv, pkgerr{Err: err, CallSite: "BoundMapping.ReadFields", Context: "field [" + name + "] in type " + b.top.String()}
+			}
+			continue
+		}
+		rv[fieldN] = readInterface(v)
+	}
+	return rv, nil
+}
+
 // Rebind will replace the currently bound value with the new variable v.
 //
 // v must have the same type as the original value used to create the BoundMapping
@@ -235,7 +378,7 @@ func (b BoundMapping) Fields(fields []string, rv []interface{}) ([]interface{},
 // As a convenience Rebind allows v to be an instance of reflect.Value.  This prevents
 // unnecessary calls to reflect.Value.Interface().
 func (b *BoundMapping) Rebind(v interface{}) {
-	if b.err != nil && errors.Is(b.err, ErrReadOnly) {
+	if b.err != nil && errors.Is(b.err, ErrReadOnly) && !b.readOnly {
 		return
 	}
 	//
@@ -253,27 +396,95 @@ func (b *BoundMapping) Rebind(v interface{}) {
 	if b.top != T {
 		panic(fmt.Sprintf("mismatching types during Rebind; have %v and got %T", b.top.String(), v)) // TODO ErrRebind maybe?
 	}
+	if b.readOnly {
+		b.value = readOnlyValue(rv)
+		return
+	}
 	b.err = nil
 	b.value, _ = Writable(rv)
+	if b.accessor != nil {
+		b.ptr = b.value.Addr().Interface()
+	}
+}
+
+// readOnlyValue follows v through pointers until it reaches a value that is not a pointer or
+// a nil pointer; it is used instead of Writable for read-only mappings.
+func readOnlyValue(v reflect.Value) reflect.Value {
+	for ; v.Kind() == reflect.Ptr && !v.IsNil(); v = v.Elem() {
+	}
+	return v
+}
+
+// readInterface returns v.Interface(); when v is addressable built-in primitives are read
+// through a pointer to avoid the allocations of v.Interface().
+func readInterface(v reflect.Value) interface{} {
+	if !v.CanAddr() {
+		return v.Interface()
+	}
+	switch ptr := v.Addr().Interface().(type) {
+	case *bool:
+		return *ptr
+	case *int:
+		return *ptr
+	case *int8:
+		return *ptr
+	case *int16:
+		return *ptr
+	case *int32:
+		return *ptr
+	case *int64:
+		return *ptr
+	case *uint:
+		return *ptr
+	case *uint8:
+		return *ptr
+	case *uint16:
+		return *ptr
+	case *uint32:
+		return *ptr
+	case *uint64:
+		return *ptr
+	case *float32:
+		return *ptr
+	case *float64:
+		return *ptr
+	case *string:
+		return *ptr
+	case *time.Time:
+		return *ptr
+	}
+	return v.Interface()
 }
 
 // Set effectively sets V[field] = value.
+//
+// If the Mapper has IndexSlices enabled then field can contain slice indexes and slices are
+// grown as needed to reach them.  If the Mapper has Normalize set then field is normalized
+// when it does not exactly match a key.
+//
+// If value can not be assigned to the field the error wraps a *FieldError describing the key,
+// the Go path of the field, its type, and value.
 func (b *BoundMapping) Set(field string, value interface{}) error {
 	if b.err != nil && errors.Is(b.err, ErrReadOnly) {
 		return b.err.(pkgerr).WithCallSite("BoundMapping.Set")
 	}
+	fast := !convertsSelf(b.converters, value)
+	if fast && b.accessor != nil && b.accessor.Set(b.ptr, field, value) {
+		return nil
+	}
 	//
 	step, ok := b.paths[field]
 	if !ok {
-		err := pkgerr{
-			Err:      ErrUnknownField,
-			CallSite: "BoundMapping.Set",
-			Context:  "field [" + field + "] not found in type " + b.top.String(),
-		}
-		if b.err == nil {
-			b.err = err
+		var err error
+		if step, err = b.resolver.lookup(b.paths, field, b.top, "BoundMapping.Set"); err != nil {
+			if b.err == nil {
+				b.err = err
+			}
+			return err
 		}
-		return err
+	}
+	if fast && step.Offsets != nil && unsafeSet(unsafe.Pointer(b.value.UnsafeAddr()), step.Offsets, value) {
+		return nil
 	}
 	v := b.value
 	if step.HasPointer { // NB  Begin manual inline of path.ReflectPath.Value
@@ -291,10 +502,13 @@ func (b *BoundMapping) Set(field string, value interface{}) error {
 		}
 	}
 	v = v.Field(step.Last) // NB  End manual inline of path.ReflectPath.Value
+	if step.Elem != nil {
+		v = step.Elem.Value(v)
+	}
 	//
 	// If the types are directly equatable then we might be able to avoid creating a V(fieldValue),
 	// which will cut down our allocations and increase speed.
-	if v.Type() == reflect.TypeOf(value) {
+	if fast && v.Type() == reflect.TypeOf(value) {
 		switch tt := value.(type) {
 		case bool:
 			v.SetBool(tt)
@@ -343,9 +557,12 @@ func (b *BoundMapping) Set(field string, value interface{}) error {
 	//
 	// If the type-switch above didn't hit then we'll coerce the
 	// fieldValue to a Value and use our swiss-army knife Value.To().
-	err := V(v).To(value)
-	if err != nil && b.err == nil {
-		b.err = err // TODO Possibly wrap with more information.
+	err := V(v).to(value, b.converters, false)
+	if err != nil {
+		err = setError(err, "BoundMapping.Set", b.top, field, step, v.Type(), value)
+		if b.err == nil {
+			b.err = err
+		}
 	}
 	return err
 }
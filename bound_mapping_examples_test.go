@@ -3,10 +3,36 @@ package set_test
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/nofeaturesonlybugs/set"
 )
 
+func ExampleBoundMapping_ApplyDefaults() {
+	// ApplyDefaults assigns the values in the struct tag named by Mapper.DefaultTag
+	// to fields that are still zero, such as after filling a struct from a file.
+	type Server struct {
+		Host    string        `default:"localhost"`
+		Port    int           `default:"8080"`
+		Timeout time.Duration `default:"30s"`
+	}
+	type Config struct {
+		Name   string
+		Server *Server
+	}
+	mapper := &set.Mapper{DefaultTag: "default", Join: "_"}
+
+	config := Config{Name: "app", Server: &Server{Port: 9000}}
+	b, _ := mapper.Bind(&config)
+	if err := b.ApplyDefaults(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(config.Name, config.Server.Host, config.Server.Port, config.Server.Timeout)
+
+	// Output: app localhost 9000 30s
+}
+
 func ExampleBoundMapping_Rebind() {
 	// Once a BoundMapping has been created you can swap out the value it mutates
 	// by calling Rebind.  This yields better performance in tight loops where
@@ -1,6 +1,7 @@
 package set_test
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -9,8 +10,95 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/coerce"
 )
 
+func TestBoundMapping_ApplyDefaults(t *testing.T) {
+	type DB struct {
+		Host string `default:"localhost"`
+		Port int    `default:"5432"`
+	}
+	type Config struct {
+		Name     string        `default:"app"`
+		Timeout  time.Duration `default:"30s"`
+		Retries  *int          `default:"3"`
+		Debug    bool          `default:"true"`
+		Other    string
+		DB       *DB
+		Replicas [2]DB
+		Servers  []DB
+	}
+	mapper := &set.Mapper{DefaultTag: "default", Join: "_", IndexSlices: true}
+	t.Run("mapping", func(t *testing.T) {
+		chk := assert.New(t)
+		mapping := mapper.Map(Config{})
+		chk.Equal("30s", mapping.Defaults["Timeout"])
+		chk.Equal("localhost", mapping.Defaults["DB_Host"])
+		chk.Equal("5432", mapping.Defaults["Replicas_1_Port"])
+		chk.NotContains(mapping.Defaults, "Other")
+		chk.NotContains(mapping.Defaults, "Servers_#_Host")
+		chk.Equal(mapping.Defaults, mapping.Copy().Defaults)
+		chk.Empty((&set.Mapper{Join: "_"}).Map(Config{}).Defaults)
+	})
+	t.Run("zero", func(t *testing.T) {
+		chk := assert.New(t)
+		var config Config
+		b, err := mapper.Bind(&config)
+		chk.NoError(err)
+		chk.NoError(b.ApplyDefaults())
+		chk.Equal("app", config.Name)
+		chk.Equal(30*time.Second, config.Timeout)
+		if chk.NotNil(config.Retries) {
+			chk.Equal(3, *config.Retries)
+		}
+		chk.True(config.Debug)
+		chk.Equal("", config.Other)
+		chk.Equal(&DB{Host: "localhost", Port: 5432}, config.DB)
+		chk.Equal([2]DB{{"localhost", 5432}, {"localhost", 5432}}, config.Replicas)
+		chk.Nil(config.Servers)
+	})
+	t.Run("filled", func(t *testing.T) {
+		chk := assert.New(t)
+		retries := 0
+		config := Config{Name: "mine", Retries: &retries, DB: &DB{Port: 1}}
+		config.Replicas[1].Host = "replica"
+		b, err := mapper.Bind(&config)
+		chk.NoError(err)
+		chk.NoError(b.ApplyDefaults())
+		chk.Equal("mine", config.Name)
+		chk.Equal(&DB{Host: "localhost", Port: 1}, config.DB)
+		chk.Equal(DB{Host: "replica", Port: 5432}, config.Replicas[1])
+		// Retries is a non-nil pointer so it is not zero.
+		chk.Same(&retries, config.Retries)
+		chk.Equal(0, retries)
+	})
+	t.Run("invalid default", func(t *testing.T) {
+		chk := assert.New(t)
+		type Bad struct {
+			Name string `default:"bad"`
+			N    int    `default:"abc"`
+		}
+		var bad Bad
+		b, err := mapper.Bind(&bad)
+		chk.NoError(err)
+		err = b.ApplyDefaults()
+		chk.ErrorIs(err, coerce.ErrInvalid)
+		chk.Contains(err.Error(), "BoundMapping.ApplyDefaults")
+		var fieldErr *set.FieldError
+		if chk.True(errors.As(err, &fieldErr)) {
+			chk.Equal("N", fieldErr.Key)
+			chk.Equal("abc", fieldErr.Value)
+		}
+		chk.Equal("bad", bad.Name)
+	})
+	t.Run("read only", func(t *testing.T) {
+		chk := assert.New(t)
+		b, err := mapper.BindReadOnly(Config{})
+		chk.NoError(err)
+		chk.ErrorIs(b.ApplyDefaults(), set.ErrReadOnly)
+	})
+}
+
 func TestBoundMapping_Assignables(t *testing.T) {
 	Addr := func(p interface{}) string {
 		return fmt.Sprintf("%p", p)
@@ -247,6 +335,52 @@ func TestBoundMapping_Err(t *testing.T) {
 
 }
 
+func TestBoundMapping_SetFieldError(t *testing.T) {
+	type Money struct {
+		Amount float64
+	}
+	type Line struct {
+		Qty int
+	}
+	type Order struct {
+		ID    int     `json:"id"`
+		Total *Money  `json:"total"`
+		Lines []Line  `json:"lines"`
+		Notes [2]bool `json:"notes"`
+	}
+	mapper := &set.Mapper{Tags: []string{"json"}, Join: "_", IndexSlices: true}
+	tests := []struct {
+		Key   string
+		Path  string
+		Value interface{}
+		Type  reflect.Type
+	}{
+		{"id", "ID", "abc", reflect.TypeOf(0)},
+		{"total_Amount", "Total.Amount", "abc", reflect.TypeOf(0.0)},
+		{"lines_3_Qty", "Lines[3].Qty", "many", reflect.TypeOf(0)},
+		{"notes_1", "Notes[1]", "maybe", reflect.TypeOf(false)},
+	}
+	for _, test := range tests {
+		t.Run(test.Key, func(t *testing.T) {
+			chk := assert.New(t)
+			var order Order
+			b, err := mapper.Bind(&order)
+			chk.NoError(err)
+			err = b.Set(test.Key, test.Value)
+			chk.ErrorIs(err, coerce.ErrInvalid)
+			chk.Equal(err, b.Err())
+			var fieldErr *set.FieldError
+			if chk.True(errors.As(err, &fieldErr)) {
+				chk.Equal(test.Key, fieldErr.Key)
+				chk.Equal(test.Path, fieldErr.Path)
+				chk.Equal(test.Value, fieldErr.Value)
+				chk.Equal(test.Type, fieldErr.Type)
+			}
+			chk.Contains(err.Error(), "BoundMapping.Set")
+		})
+	}
+}
+
 func TestBoundMapping_Fields(t *testing.T) {
 	type Test struct {
 		Name   string
@@ -430,3 +564,88 @@ func TestBoundMapping_Fields(t *testing.T) {
 		}
 	})
 }
+
+func TestBoundMapping_ReadFields(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  *string
+	}
+	type Item struct {
+		Name string
+	}
+	type Person struct {
+		Name    string
+		Age     int
+		Address *Address
+		Items   []Item
+		Scores  [2]float64
+	}
+	mapper := &set.Mapper{Join: "_", IndexSlices: true}
+	fields := []string{"Name", "Age", "Address_City", "Address_Zip", "Items_1_Name", "Scores_1"}
+	t.Run("bind read only", func(t *testing.T) {
+		chk := assert.New(t)
+		p := Person{Name: "Alice", Age: 30, Scores: [2]float64{1, 2}}
+		//
+		// A struct that is not addressable.
+		b, err := mapper.BindReadOnly(p)
+		chk.NoError(err)
+		values, err := b.ReadFields(fields, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"Alice", 30, nil, nil, nil, 2.0}, values)
+		chk.Nil(p.Address)
+		//
+		zip := "02134"
+		b.Rebind(Person{Name: "Bob", Address: &Address{City: "Boston", Zip: &zip}, Items: []Item{{"a"}, {"b"}}})
+		values, err = b.ReadFields(fields, values)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"Bob", 0, "Boston", &zip, "b", 0.0}, values)
+		//
+		// Mutating methods return ErrReadOnly.
+		chk.ErrorIs(b.Set("Name", "Carol"), set.ErrReadOnly)
+		_, err = b.Fields(fields, nil)
+		chk.ErrorIs(err, set.ErrReadOnly)
+		_, err = b.Assignables(fields, nil)
+		chk.ErrorIs(err, set.ErrReadOnly)
+		_, err = b.Field("Name")
+		chk.ErrorIs(err, set.ErrReadOnly)
+		chk.ErrorIs(b.Copy().Err(), set.ErrReadOnly)
+		//
+		_, err = b.ReadFields([]string{"Unknown"}, nil)
+		chk.ErrorIs(err, set.ErrUnknownField)
+		//
+		// Nil pointers.
+		b, err = mapper.BindReadOnly((*Person)(nil))
+		chk.NoError(err)
+		values, err = b.ReadFields(fields, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{nil, nil, nil, nil, nil, nil}, values)
+		b.Rebind(&p)
+		values, err = b.ReadFields(fields[:1], nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"Alice"}, values)
+	})
+	t.Run("bind", func(t *testing.T) {
+		chk := assert.New(t)
+		var p Person
+		b, err := mapper.Bind(&p)
+		chk.NoError(err)
+		values, err := b.ReadFields(fields, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"", 0, nil, nil, nil, 0.0}, values)
+		chk.Nil(p.Address)
+		chk.Nil(p.Items)
+		//
+		b, err = mapper.Bind(p)
+		chk.ErrorIs(err, set.ErrReadOnly)
+		_, err = b.ReadFields(fields, nil)
+		chk.ErrorIs(err, set.ErrReadOnly)
+	})
+	t.Run("sql adapters", func(t *testing.T) {
+		chk := assert.New(t)
+		b, err := (&set.Mapper{Join: "_", SQLAdapters: true}).BindReadOnly(Person{Age: 5})
+		chk.NoError(err)
+		values, err := b.ReadFields([]string{"Age", "Address_City", "Address_Zip"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{int64(5), nil, nil}, values)
+	})
+}
@@ -0,0 +1,405 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// setPath is the import path of the set package.
+const setPath = "github.com/nofeaturesonlybugs/set"
+
+// config describes the types to generate accessors for and the set.Mapper rules used to
+// generate their keys.
+type config struct {
+	// Dir is the directory of the package containing Types.
+	Dir   string
+	Types []string
+
+	// Tags, Join, Transform, Elevated, and Scalars correspond to the set.Mapper fields
+	// Tags, Join, Transform, Elevated, and TreatAsScalar.  Elevated and Scalars contain
+	// type names such as Timestamps, sql.NullString, or database/sql.NullString.
+	Tags      []string
+	Join      string
+	Transform func(string) string
+	Elevated  []string
+	Scalars   []string
+
+	// Output is the file name of the generated code; it is excluded when loading the package.
+	Output string
+
+	// Command is written in the header of the generated code.
+	Command string
+}
+
+// leaf is a field mapped by a key.
+type leaf struct {
+	key   string
+	index []int
+
+	// fields are the struct fields from the top of the struct hierarchy to the leaf.
+	fields []*types.Var
+}
+
+// generator generates the accessors for a single package.
+type generator struct {
+	config
+	pkg *types.Package
+
+	// imports maps import paths to the names used in the generated code.
+	imports map[string]string
+	body    bytes.Buffer
+}
+
+// generate loads the package described by c and returns the formatted source code of its accessors.
+func generate(c config) ([]byte, error) {
+	pkg, err := load(c.Dir, c.Output)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		config:  c,
+		pkg:     pkg,
+		imports: map[string]string{setPath: "set"},
+	}
+	for _, name := range c.Types {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type %v not found in package %v", name, pkg.Name())
+		} else if _, ok = obj.Type().Underlying().(*types.Struct); !ok {
+			return nil, fmt.Errorf("type %v is not a struct", name)
+		}
+		g.generate(obj)
+	}
+	return g.source()
+}
+
+// load parses and type checks the package in dir excluding the file named exclude.
+func load(dir string, exclude string) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		if name == exclude {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check(bp.Name, fset, files, nil)
+}
+
+// source returns the formatted source code.
+func (g *generator) source() ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"%v\"; DO NOT EDIT.\n\n", g.Command)
+	fmt.Fprintf(&buf, "package %v\n\n", g.pkg.Name())
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	buf.WriteString("import (\n")
+	for _, path := range paths {
+		name := g.imports[path]
+		if name == filepath.Base(path) {
+			fmt.Fprintf(&buf, "%q\n", path)
+		} else {
+			fmt.Fprintf(&buf, "%v %q\n", name, path)
+		}
+	}
+	buf.WriteString(")\n")
+	buf.Write(g.body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+// generate writes the accessor for the named struct type obj.
+func (g *generator) generate(obj *types.TypeName) {
+	leaves := g.leaves(obj.Type())
+	name := obj.Name()
+	accessor := lowerFirst(name) + "Accessor"
+	register := "register" + upperFirst(name) + "Accessor"
+	if obj.Exported() {
+		register = "R" + register[1:]
+	}
+	w := &g.body
+	//
+	fmt.Fprintf(w, "\n// %v implements set.Accessor for %v.\ntype %v struct{}\n", accessor, name, accessor)
+	fmt.Fprintf(w, "\n// %v registers the accessor for %v with mapper.\n", register, name)
+	fmt.Fprintf(w, "func %v(mapper *set.Mapper) error {\nreturn mapper.RegisterAccessor(%v{}, %v{})\n}\n", register, name, accessor)
+	//
+	fmt.Fprintf(w, "\n// Indeces implements set.Accessor.\nfunc (%v) Indeces() map[string][]int {\nreturn map[string][]int{\n", accessor)
+	for _, leaf := range leaves {
+		index := make([]string, len(leaf.index))
+		for k, n := range leaf.index {
+			index[k] = strconv.Itoa(n)
+		}
+		fmt.Fprintf(w, "%q: {%v},\n", leaf.key, strings.Join(index, ", "))
+	}
+	w.WriteString("}\n}\n")
+	//
+	fmt.Fprintf(w, "\n// Set implements set.Accessor.\nfunc (%v) Set(v interface{}, key string, value interface{}) bool {\n", accessor)
+	var settable []leaf
+	for _, leaf := range leaves {
+		if basic, ok := leaf.fields[len(leaf.fields)-1].Type().(*types.Basic); ok && isScalar(basic) {
+			settable = append(settable, leaf)
+		}
+	}
+	if len(settable) > 0 {
+		fmt.Fprintf(w, "x := v.(*%v)\nswitch key {\n", name)
+		for _, leaf := range settable {
+			alloc, expr := g.selector(leaf.fields)
+			fmt.Fprintf(w, "case %q:\nif tt, ok := value.(%v); ok {\n%v%v = tt\nreturn true\n}\n", leaf.key, leaf.fields[len(leaf.fields)-1].Type(), alloc, expr)
+		}
+		w.WriteString("}\n")
+	}
+	w.WriteString("return false\n}\n")
+	//
+	for _, method := range []string{"Assignable", "Field"} {
+		fmt.Fprintf(w, "\n// %v implements set.Accessor.\nfunc (%v) %v(v interface{}, key string) (interface{}, bool) {\n", method, accessor, method)
+		if len(leaves) > 0 {
+			fmt.Fprintf(w, "x := v.(*%v)\nswitch key {\n", name)
+			for _, leaf := range leaves {
+				alloc, expr := g.selector(leaf.fields)
+				if method == "Assignable" {
+					expr = "&" + expr
+				}
+				fmt.Fprintf(w, "case %q:\n%vreturn %v, true\n", leaf.key, alloc, expr)
+			}
+			w.WriteString("}\n")
+		}
+		w.WriteString("return nil, false\n}\n")
+	}
+}
+
+// leaves returns the fields of the struct type T in the same order and with the same keys as
+// set.Mapper.Map.  Elements of arrays and slices are not included.
+func (g *generator) leaves(T types.Type) []leaf {
+	var rv []leaf
+	position := map[string]int{}
+	active := map[types.Type]int{}
+	//
+	var scan func(T types.Type, index []int, prefix string, fields []*types.Var)
+	scan = func(T types.Type, index []int, prefix string, fields []*types.Var) {
+		active[T]++
+		defer func() { active[T]-- }()
+		st := T.Underlying().(*types.Struct)
+		for k := 0; k < st.NumFields(); k++ {
+			field := st.Field(k)
+			if !field.Exported() {
+				continue
+			}
+			fieldT := deref(field.Type())
+			name, skip := g.fieldName(field, st.Tag(k), fieldT)
+			if skip {
+				continue
+			}
+			if prefix != "" && name != "" {
+				name = prefix + g.Join + name
+			} else if prefix != "" {
+				name = prefix
+			}
+			fieldIndex := append(append([]int(nil), index...), k)
+			fieldFields := append(append([]*types.Var(nil), fields...), field)
+			basic, isBasic := fieldT.Underlying().(*types.Basic)
+			if _, isStruct := fieldT.Underlying().(*types.Struct); g.isTime(fieldT) || g.has(g.Scalars, fieldT) || (isBasic && isScalar(basic)) {
+				// NB  As with set.Mapping.Indeces the last field mapped to a key wins.
+				if n, ok := position[name]; ok {
+					rv[n] = leaf{key: name, index: fieldIndex, fields: fieldFields}
+					continue
+				}
+				position[name] = len(rv)
+				rv = append(rv, leaf{key: name, index: fieldIndex, fields: fieldFields})
+			} else if isStruct && active[fieldT] == 0 {
+				scan(fieldT, fieldIndex, name, fieldFields)
+			}
+		}
+	}
+	scan(T, nil, "", nil)
+	//
+	// Fields that can not be reached by the generated code are left to reflect.
+	allocatable := rv[:0]
+	for _, leaf := range rv {
+		if g.allocatable(leaf.fields) {
+			allocatable = append(allocatable, leaf)
+		}
+	}
+	return allocatable
+}
+
+// fieldName returns the name for field in the same manner as set.Mapper; T is the type of the
+// field after following pointers.  skip is true if the struct tag is "-".
+func (g *generator) fieldName(field *types.Var, tag string, T types.Type) (name string, skip bool) {
+	if g.has(g.Elevated, T) {
+		return "", false
+	}
+	for _, tagName := range g.Tags {
+		value, ok := reflect.StructTag(tag).Lookup(tagName)
+		if !ok {
+			continue
+		} else if value == "-" {
+			return "", true
+		}
+		if n := strings.Index(value, ","); n != -1 {
+			value = value[:n]
+		}
+		if value != "" {
+			return value, false
+		}
+		break
+	}
+	name = field.Name()
+	if g.Transform != nil {
+		name = g.Transform(name)
+	}
+	return name, false
+}
+
+// has returns true if the named type T is in names.
+func (g *generator) has(names []string, T types.Type) bool {
+	named, ok := T.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	obj := named.Obj()
+	for _, name := range names {
+		if obj.Pkg() == g.pkg && name == obj.Name() {
+			return true
+		} else if name == obj.Pkg().Name()+"."+obj.Name() || name == obj.Pkg().Path()+"."+obj.Name() {
+			return true
+		}
+	}
+	return false
+}
+
+// isTime returns true if T is time.Time.
+func (g *generator) isTime(T types.Type) bool {
+	named, ok := T.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+// allocatable returns true if the pointers between the top of the struct hierarchy and the
+// last field can be instantiated by the generated code.
+func (g *generator) allocatable(fields []*types.Var) bool {
+	for _, field := range fields[:len(fields)-1] {
+		for T := field.Type(); ; {
+			ptr, ok := T.Underlying().(*types.Pointer)
+			if !ok {
+				break
+			}
+			T = ptr.Elem()
+			if named, ok := T.(*types.Named); ok && named.Obj().Pkg() != g.pkg && !named.Obj().Exported() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// selector returns the Go expression for the last of fields starting from the variable x along
+// with the statements that instantiate nil pointers before it.
+func (g *generator) selector(fields []*types.Var) (alloc string, expr string) {
+	expr = "x"
+	for k, field := range fields {
+		if strings.HasPrefix(expr, "*") {
+			expr = "(" + expr + ")"
+		}
+		expr += "." + field.Name()
+		if k == len(fields)-1 {
+			break
+		}
+		for T := field.Type(); ; {
+			ptr, ok := T.Underlying().(*types.Pointer)
+			if !ok {
+				break
+			}
+			T = ptr.Elem()
+			alloc += fmt.Sprintf("if %v == nil {\n%v = new(%v)\n}\n", expr, expr, types.TypeString(T, g.qualifier))
+			if _, ok = T.Underlying().(*types.Pointer); ok {
+				expr = "*" + expr
+			}
+		}
+	}
+	return alloc, expr
+}
+
+// qualifier returns the name used for pkg in the generated code and records the import.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	} else if name, ok := g.imports[pkg.Path()]; ok {
+		return name
+	}
+	name := pkg.Name()
+	for n := 2; g.imported(name); n++ {
+		name = pkg.Name() + strconv.Itoa(n)
+	}
+	g.imports[pkg.Path()] = name
+	return name
+}
+
+// imported returns true if name is used by an import.
+func (g *generator) imported(name string) bool {
+	for _, have := range g.imports {
+		if have == name {
+			return true
+		}
+	}
+	return false
+}
+
+// deref returns T after following pointers.
+func deref(T types.Type) types.Type {
+	for {
+		ptr, ok := T.Underlying().(*types.Pointer)
+		if !ok {
+			return T
+		}
+		T = ptr.Elem()
+	}
+}
+
+// isScalar returns true if basic is a kind that set.Mapper maps as a scalar.
+func isScalar(basic *types.Basic) bool {
+	switch basic.Kind() {
+	case types.Bool,
+		types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+		types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64,
+		types.Float32, types.Float64,
+		types.String:
+		return true
+	}
+	return false
+}
+
+// lowerFirst returns s with its first rune in lower case.
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+// upperFirst returns s with its first rune in upper case.
+func upperFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
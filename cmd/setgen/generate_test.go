@@ -0,0 +1,117 @@
+package main
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestGenerate(t *testing.T) {
+	// The generated files in internal/shop must be up to date.
+	tests := []config{
+		{
+			Types:    []string{"Order"},
+			Tags:     []string{"db", "json"},
+			Join:     "_",
+			Elevated: []string{"Timestamps"},
+			Scalars:  []string{"sql.NullString"},
+			Output:   "order_setgen.go",
+			Command:  "setgen -type Order -tags db,json -elevated Timestamps -scalar sql.NullString",
+		},
+		{
+			Types:     []string{"Contact"},
+			Join:      "_",
+			Transform: set.SnakeCase,
+			Output:    "contact_setgen.go",
+			Command:   "setgen -type Contact -transform snake",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Output, func(t *testing.T) {
+			chk := assert.New(t)
+			test.Dir = filepath.Join("internal", "shop")
+			src, err := generate(test)
+			chk.NoError(err)
+			expect, err := ioutil.ReadFile(filepath.Join(test.Dir, test.Output))
+			chk.NoError(err)
+			chk.Equal(string(expect), string(src), "run go generate in "+test.Dir)
+		})
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	chk := assert.New(t)
+	dir := filepath.Join("internal", "shop")
+	_, err := generate(config{Dir: dir, Types: []string{"Missing"}})
+	chk.Error(err)
+	_, err = generate(config{Dir: dir, Types: []string{"Level"}})
+	chk.Error(err)
+	_, err = generate(config{Dir: filepath.Join("testdata", "missing"), Types: []string{"T"}})
+	chk.Error(err)
+}
+
+func TestLeaves(t *testing.T) {
+	chk := assert.New(t)
+	pkg, err := load(filepath.Join("testdata", "rules"), "")
+	chk.NoError(err)
+	g := &generator{
+		config: config{
+			Tags:     []string{"db", "json"},
+			Join:     "_",
+			Elevated: []string{"rules.Audit"},
+			Scalars:  []string{"database/sql.NullInt64"},
+		},
+		pkg:     pkg,
+		imports: map[string]string{},
+	}
+	var keys []string
+	indeces := map[string][]int{}
+	for _, leaf := range g.leaves(pkg.Scope().Lookup("T").Type()) {
+		keys = append(keys, leaf.key)
+		indeces[leaf.key] = leaf.index
+	}
+	chk.Equal([]string{"A", "see", "By", "Null", "Node_Value"}, keys)
+	chk.Equal([]int{3}, indeces["A"])
+	chk.Equal([]int{7, 0}, indeces["Node_Value"])
+	//
+	// The keys must agree with set.Mapper; array elements are left to reflect.
+	type Audit struct{ By string }
+	type node struct {
+		Value int
+		Next  *node
+	}
+	type T struct {
+		A     string `db:",omitempty"`
+		B     string `db:"-"`
+		C     string `json:"c" db:"see"`
+		Dup   string `db:"A"`
+		inner struct{ Hidden string }
+		Audit
+		Null sql.NullInt64
+		Node *node
+		Vec  [2]int
+		Ch   chan int
+	}
+	mapping := (&set.Mapper{
+		Tags:          g.Tags,
+		Join:          g.Join,
+		Elevated:      set.NewTypeList(Audit{}),
+		TreatAsScalar: set.NewTypeList(sql.NullInt64{}),
+	}).Map(T{})
+	var expect []string
+	for _, key := range mapping.Keys {
+		if !strings.HasPrefix(key, "Vec") && (len(expect) == 0 || key != "A") {
+			expect = append(expect, key)
+		}
+	}
+	chk.Equal(expect, keys)
+	for _, key := range keys {
+		chk.Equal(mapping.Indeces[key], indeces[key], key)
+	}
+}
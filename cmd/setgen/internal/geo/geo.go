@@ -0,0 +1,8 @@
+// Package geo contains types used by package shop from another package; it is used to test setgen.
+package geo
+
+// Point is reached through a pointer from package shop.
+type Point struct {
+	Lat float64
+	Lng float64
+}
@@ -0,0 +1,129 @@
+// Code generated by "setgen -type Contact -transform snake"; DO NOT EDIT.
+
+package shop
+
+import (
+	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/cmd/setgen/internal/geo"
+)
+
+// contactAccessor implements set.Accessor for Contact.
+type contactAccessor struct{}
+
+// RegisterContactAccessor registers the accessor for Contact with mapper.
+func RegisterContactAccessor(mapper *set.Mapper) error {
+	return mapper.RegisterAccessor(Contact{}, contactAccessor{})
+}
+
+// Indeces implements set.Accessor.
+func (contactAccessor) Indeces() map[string][]int {
+	return map[string][]int{
+		"first_name":          {0},
+		"last_name":           {1},
+		"home_address_street": {2, 0},
+		"home_address_city":   {2, 1},
+		"phone":               {3},
+		"location_lat":        {4, 0},
+		"location_lng":        {4, 1},
+	}
+}
+
+// Set implements set.Accessor.
+func (contactAccessor) Set(v interface{}, key string, value interface{}) bool {
+	x := v.(*Contact)
+	switch key {
+	case "first_name":
+		if tt, ok := value.(string); ok {
+			x.FirstName = tt
+			return true
+		}
+	case "last_name":
+		if tt, ok := value.(string); ok {
+			x.LastName = tt
+			return true
+		}
+	case "home_address_street":
+		if tt, ok := value.(string); ok {
+			x.HomeAddress.Street = tt
+			return true
+		}
+	case "home_address_city":
+		if tt, ok := value.(string); ok {
+			x.HomeAddress.City = tt
+			return true
+		}
+	case "location_lat":
+		if tt, ok := value.(float64); ok {
+			if x.Location == nil {
+				x.Location = new(geo.Point)
+			}
+			x.Location.Lat = tt
+			return true
+		}
+	case "location_lng":
+		if tt, ok := value.(float64); ok {
+			if x.Location == nil {
+				x.Location = new(geo.Point)
+			}
+			x.Location.Lng = tt
+			return true
+		}
+	}
+	return false
+}
+
+// Assignable implements set.Accessor.
+func (contactAccessor) Assignable(v interface{}, key string) (interface{}, bool) {
+	x := v.(*Contact)
+	switch key {
+	case "first_name":
+		return &x.FirstName, true
+	case "last_name":
+		return &x.LastName, true
+	case "home_address_street":
+		return &x.HomeAddress.Street, true
+	case "home_address_city":
+		return &x.HomeAddress.City, true
+	case "phone":
+		return &x.Phone, true
+	case "location_lat":
+		if x.Location == nil {
+			x.Location = new(geo.Point)
+		}
+		return &x.Location.Lat, true
+	case "location_lng":
+		if x.Location == nil {
+			x.Location = new(geo.Point)
+		}
+		return &x.Location.Lng, true
+	}
+	return nil, false
+}
+
+// Field implements set.Accessor.
+func (contactAccessor) Field(v interface{}, key string) (interface{}, bool) {
+	x := v.(*Contact)
+	switch key {
+	case "first_name":
+		return x.FirstName, true
+	case "last_name":
+		return x.LastName, true
+	case "home_address_street":
+		return x.HomeAddress.Street, true
+	case "home_address_city":
+		return x.HomeAddress.City, true
+	case "phone":
+		return x.Phone, true
+	case "location_lat":
+		if x.Location == nil {
+			x.Location = new(geo.Point)
+		}
+		return x.Location.Lat, true
+	case "location_lng":
+		if x.Location == nil {
+			x.Location = new(geo.Point)
+		}
+		return x.Location.Lng, true
+	}
+	return nil, false
+}
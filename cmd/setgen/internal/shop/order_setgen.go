@@ -0,0 +1,225 @@
+// Code generated by "setgen -type Order -tags db,json -elevated Timestamps -scalar sql.NullString"; DO NOT EDIT.
+
+package shop
+
+import (
+	"github.com/nofeaturesonlybugs/set"
+)
+
+// orderAccessor implements set.Accessor for Order.
+type orderAccessor struct{}
+
+// RegisterOrderAccessor registers the accessor for Order with mapper.
+func RegisterOrderAccessor(mapper *set.Mapper) error {
+	return mapper.RegisterAccessor(Order{}, orderAccessor{})
+}
+
+// Indeces implements set.Accessor.
+func (orderAccessor) Indeces() map[string][]int {
+	return map[string][]int{
+		"id":                      {0},
+		"total":                   {1},
+		"paid":                    {2},
+		"note":                    {3},
+		"level":                   {4},
+		"created":                 {7, 0},
+		"modified":                {7, 1},
+		"customer_id":             {8, 0},
+		"customer_name":           {8, 1},
+		"customer_address_street": {8, 2, 0},
+		"customer_address_city":   {8, 2, 1},
+	}
+}
+
+// Set implements set.Accessor.
+func (orderAccessor) Set(v interface{}, key string, value interface{}) bool {
+	x := v.(*Order)
+	switch key {
+	case "id":
+		if tt, ok := value.(int); ok {
+			x.ID = tt
+			return true
+		}
+	case "total":
+		if tt, ok := value.(float64); ok {
+			x.Total = tt
+			return true
+		}
+	case "paid":
+		if tt, ok := value.(bool); ok {
+			x.Paid = tt
+			return true
+		}
+	case "customer_id":
+		if tt, ok := value.(int); ok {
+			if x.Customer == nil {
+				x.Customer = new(*Customer)
+			}
+			if *x.Customer == nil {
+				*x.Customer = new(Customer)
+			}
+			(*x.Customer).ID = tt
+			return true
+		}
+	case "customer_name":
+		if tt, ok := value.(string); ok {
+			if x.Customer == nil {
+				x.Customer = new(*Customer)
+			}
+			if *x.Customer == nil {
+				*x.Customer = new(Customer)
+			}
+			(*x.Customer).Name = tt
+			return true
+		}
+	case "customer_address_street":
+		if tt, ok := value.(string); ok {
+			if x.Customer == nil {
+				x.Customer = new(*Customer)
+			}
+			if *x.Customer == nil {
+				*x.Customer = new(Customer)
+			}
+			if (*x.Customer).Address == nil {
+				(*x.Customer).Address = new(Address)
+			}
+			(*x.Customer).Address.Street = tt
+			return true
+		}
+	case "customer_address_city":
+		if tt, ok := value.(string); ok {
+			if x.Customer == nil {
+				x.Customer = new(*Customer)
+			}
+			if *x.Customer == nil {
+				*x.Customer = new(Customer)
+			}
+			if (*x.Customer).Address == nil {
+				(*x.Customer).Address = new(Address)
+			}
+			(*x.Customer).Address.City = tt
+			return true
+		}
+	}
+	return false
+}
+
+// Assignable implements set.Accessor.
+func (orderAccessor) Assignable(v interface{}, key string) (interface{}, bool) {
+	x := v.(*Order)
+	switch key {
+	case "id":
+		return &x.ID, true
+	case "total":
+		return &x.Total, true
+	case "paid":
+		return &x.Paid, true
+	case "note":
+		return &x.Note, true
+	case "level":
+		return &x.Level, true
+	case "created":
+		return &x.Timestamps.Created, true
+	case "modified":
+		return &x.Timestamps.Modified, true
+	case "customer_id":
+		if x.Customer == nil {
+			x.Customer = new(*Customer)
+		}
+		if *x.Customer == nil {
+			*x.Customer = new(Customer)
+		}
+		return &(*x.Customer).ID, true
+	case "customer_name":
+		if x.Customer == nil {
+			x.Customer = new(*Customer)
+		}
+		if *x.Customer == nil {
+			*x.Customer = new(Customer)
+		}
+		return &(*x.Customer).Name, true
+	case "customer_address_street":
+		if x.Customer == nil {
+			x.Customer = new(*Customer)
+		}
+		if *x.Customer == nil {
+			*x.Customer = new(Customer)
+		}
+		if (*x.Customer).Address == nil {
+			(*x.Customer).Address = new(Address)
+		}
+		return &(*x.Customer).Address.Street, true
+	case "customer_address_city":
+		if x.Customer == nil {
+			x.Customer = new(*Customer)
+		}
+		if *x.Customer == nil {
+			*x.Customer = new(Customer)
+		}
+		if (*x.Customer).Address == nil {
+			(*x.Customer).Address = new(Address)
+		}
+		return &(*x.Customer).Address.City, true
+	}
+	return nil, false
+}
+
+// Field implements set.Accessor.
+func (orderAccessor) Field(v interface{}, key string) (interface{}, bool) {
+	x := v.(*Order)
+	switch key {
+	case "id":
+		return x.ID, true
+	case "total":
+		return x.Total, true
+	case "paid":
+		return x.Paid, true
+	case "note":
+		return x.Note, true
+	case "level":
+		return x.Level, true
+	case "created":
+		return x.Timestamps.Created, true
+	case "modified":
+		return x.Timestamps.Modified, true
+	case "customer_id":
+		if x.Customer == nil {
+			x.Customer = new(*Customer)
+		}
+		if *x.Customer == nil {
+			*x.Customer = new(Customer)
+		}
+		return (*x.Customer).ID, true
+	case "customer_name":
+		if x.Customer == nil {
+			x.Customer = new(*Customer)
+		}
+		if *x.Customer == nil {
+			*x.Customer = new(Customer)
+		}
+		return (*x.Customer).Name, true
+	case "customer_address_street":
+		if x.Customer == nil {
+			x.Customer = new(*Customer)
+		}
+		if *x.Customer == nil {
+			*x.Customer = new(Customer)
+		}
+		if (*x.Customer).Address == nil {
+			(*x.Customer).Address = new(Address)
+		}
+		return (*x.Customer).Address.Street, true
+	case "customer_address_city":
+		if x.Customer == nil {
+			x.Customer = new(*Customer)
+		}
+		if *x.Customer == nil {
+			*x.Customer = new(Customer)
+		}
+		if (*x.Customer).Address == nil {
+			(*x.Customer).Address = new(Address)
+		}
+		return (*x.Customer).Address.City, true
+	}
+	return nil, false
+}
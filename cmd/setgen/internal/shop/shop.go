@@ -0,0 +1,59 @@
+// Package shop contains types with generated accessors; it is used to test setgen.
+package shop
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/nofeaturesonlybugs/set/cmd/setgen/internal/geo"
+)
+
+//go:generate go run ../.. -type Order -tags db,json -elevated Timestamps -scalar sql.NullString
+//go:generate go run ../.. -type Contact -transform snake
+
+// Level is a named scalar type.
+type Level int
+
+// Timestamps is elevated into its parent by the Mapper.
+type Timestamps struct {
+	Created  time.Time  `db:"created"`
+	Modified *time.Time `db:"modified"`
+}
+
+// Address is a nested struct.
+type Address struct {
+	Street string `db:"street"`
+	City   string `db:"city"`
+}
+
+// Customer is reached through pointers and refers to itself.
+type Customer struct {
+	ID       int       `db:"id"`
+	Name     string    `db:"name"`
+	Address  *Address  `db:"address"`
+	Referrer *Customer `db:"referrer"`
+}
+
+// Order is generated with struct tags.
+type Order struct {
+	ID       int            `db:"id"`
+	Total    float64        `json:"total"`
+	Paid     bool           `db:"paid,omitempty"`
+	Note     sql.NullString `db:"note"`
+	Level    Level          `db:"level"`
+	Secret   string         `db:"-"`
+	internal string
+	Timestamps
+	Customer **Customer `db:"customer"`
+	Lines    [2]int     `db:"lines"`
+	Tags     []string   `db:"tags"`
+}
+
+// Contact is generated with a name transform.
+type Contact struct {
+	FirstName   string
+	LastName    string
+	HomeAddress Address
+	Phone       *string
+	Location    *geo.Point
+}
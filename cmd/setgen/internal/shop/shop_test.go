@@ -0,0 +1,146 @@
+package shop_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/cmd/setgen/internal/geo"
+	"github.com/nofeaturesonlybugs/set/cmd/setgen/internal/shop"
+)
+
+// newOrderMapper returns a Mapper with the same rules as the go:generate line for Order.
+func newOrderMapper() *set.Mapper {
+	return &set.Mapper{
+		Tags:          []string{"db", "json"},
+		Join:          "_",
+		Elevated:      set.NewTypeList(shop.Timestamps{}),
+		TreatAsScalar: set.NewTypeList(sql.NullString{}),
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("order", func(t *testing.T) {
+		chk := assert.New(t)
+		mapper := newOrderMapper()
+		chk.NoError(shop.RegisterOrderAccessor(mapper))
+		chk.ErrorIs(shop.RegisterOrderAccessor(&set.Mapper{Tags: []string{"db"}, Join: "_"}), set.ErrAccessorMismatch)
+		chk.ErrorIs(shop.RegisterOrderAccessor(set.DefaultMapper), set.ErrAccessorMismatch)
+	})
+	t.Run("contact", func(t *testing.T) {
+		chk := assert.New(t)
+		chk.NoError(shop.RegisterContactAccessor(&set.Mapper{Join: "_", Transform: set.SnakeCase}))
+		chk.ErrorIs(shop.RegisterContactAccessor(&set.Mapper{Join: "_", Transform: set.CamelCase}), set.ErrAccessorMismatch)
+	})
+}
+
+func TestAccessor(t *testing.T) {
+	// The BoundMapping using the accessor must behave the same as one using reflect.
+	when := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	keys := []string{
+		"id", "total", "paid", "note", "level", "created", "modified",
+		"customer_id", "customer_name", "customer_address_street", "customer_address_city",
+		"lines_1",
+	}
+	values := []interface{}{
+		42, "9.5", true, "note", "3", when, &when,
+		int64(7), "Bob", "Main St", "Springfield",
+		10,
+	}
+	accessorMapper, reflectMapper := newOrderMapper(), newOrderMapper()
+	if err := shop.RegisterOrderAccessor(accessorMapper); err != nil {
+		t.Fatal(err)
+	}
+	var orders [2]shop.Order
+	for k, mapper := range []*set.Mapper{accessorMapper, reflectMapper} {
+		chk := assert.New(t)
+		bound, err := mapper.Bind(&shop.Order{})
+		chk.NoError(err)
+		bound.Rebind(&orders[k])
+		for n, key := range keys {
+			chk.NoError(bound.Set(key, values[n]), key)
+		}
+		chk.Error(bound.Set("unknown", 1))
+	}
+	chk := assert.New(t)
+	chk.Equal(orders[1], orders[0])
+	chk.Equal("Springfield", (*orders[0].Customer).Address.City)
+	//
+	var fields, assignables [2][]interface{}
+	for k, mapper := range []*set.Mapper{accessorMapper, reflectMapper} {
+		bound, err := mapper.Bind(&orders[k])
+		chk.NoError(err)
+		fields[k], err = bound.Fields(keys, nil)
+		chk.NoError(err)
+		assignables[k], err = bound.Assignables(keys, nil)
+		chk.NoError(err)
+	}
+	chk.Equal(fields[1], fields[0])
+	for n := range keys {
+		chk.IsType(assignables[1][n], assignables[0][n])
+	}
+	chk.Same(&orders[0].Level, assignables[0][4])
+	//
+	// Copy and the zero value of new instances.
+	var empty shop.Order
+	bound, err := accessorMapper.Bind(&orders[0])
+	chk.NoError(err)
+	copied := bound.Copy()
+	copied.Rebind(&empty)
+	chk.NoError(copied.Set("customer_name", "Alice"))
+	chk.Equal("Alice", (*empty.Customer).Name)
+	chk.Equal("Bob", (*orders[0].Customer).Name)
+}
+
+func TestAccessor_Contact(t *testing.T) {
+	chk := assert.New(t)
+	mapper := &set.Mapper{Join: "_", Transform: set.SnakeCase}
+	chk.NoError(shop.RegisterContactAccessor(mapper))
+	var contact shop.Contact
+	bound, err := mapper.Bind(&contact)
+	chk.NoError(err)
+	chk.NoError(bound.Set("first_name", "Bob"))
+	chk.NoError(bound.Set("location_lat", 1.5))
+	chk.NoError(bound.Set("phone", "555-1234"))
+	chk.Equal(shop.Contact{FirstName: "Bob", Phone: contact.Phone, Location: &geo.Point{Lat: 1.5}}, contact)
+	chk.Equal("555-1234", *contact.Phone)
+}
+
+func BenchmarkAccessor(b *testing.B) {
+	keys := []string{"id", "total", "paid", "customer_id", "customer_name", "customer_address_city"}
+	values := []interface{}{42, 9.5, true, 7, "Bob", "Springfield"}
+	for _, register := range []bool{false, true} {
+		mapper := newOrderMapper()
+		name := "reflect"
+		if register {
+			name = "accessor"
+			if err := shop.RegisterOrderAccessor(mapper); err != nil {
+				b.Fatal(err)
+			}
+		}
+		var order shop.Order
+		bound, err := mapper.Bind(&order)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run("Set "+name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bound.Rebind(&order)
+				for k, key := range keys {
+					_ = bound.Set(key, values[k])
+				}
+			}
+		})
+		b.Run("Fields "+name, func(b *testing.B) {
+			rv := make([]interface{}, len(keys))
+			for n := 0; n < b.N; n++ {
+				if rv, err = bound.Fields(keys, rv); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+// transforms are the values accepted by the -transform flag.
+var transforms = map[string]func(string) string{
+	"camel":     set.CamelCase,
+	"kebab":     set.KebabCase,
+	"lower":     set.LowerCase,
+	"screaming": set.ScreamingSnakeCase,
+	"snake":     set.SnakeCase,
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("setgen: ")
+	//
+	typeNames := flag.String("type", "", "comma separated list of struct type names; required")
+	tags := flag.String("tags", "", "comma separated list of struct tags; see Mapper.Tags")
+	join := flag.String("join", "_", "string used to join names; see Mapper.Join")
+	transform := flag.String("transform", "", "name transform: camel, kebab, lower, screaming, or snake; see Mapper.Transform")
+	elevated := flag.String("elevated", "", "comma separated list of type names; see Mapper.Elevated")
+	scalars := flag.String("scalar", "", "comma separated list of type names; see Mapper.TreatAsScalar")
+	output := flag.String("output", "", "output file name; default <type>_setgen.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of setgen:\n")
+		fmt.Fprintf(os.Stderr, "\tsetgen [flags] -type T [directory]\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	//
+	c := config{
+		Dir:      ".",
+		Types:    split(*typeNames),
+		Tags:     split(*tags),
+		Join:     *join,
+		Elevated: split(*elevated),
+		Scalars:  split(*scalars),
+		Output:   *output,
+		Command:  "setgen " + strings.Join(os.Args[1:], " "),
+	}
+	if flag.NArg() == 1 {
+		c.Dir = flag.Arg(0)
+	}
+	if *transform != "" {
+		var ok bool
+		if c.Transform, ok = transforms[*transform]; !ok {
+			log.Fatalf("unknown transform %q", *transform)
+		}
+	}
+	if c.Output == "" {
+		c.Output = strings.ToLower(c.Types[0]) + "_setgen.go"
+	}
+	//
+	src, err := generate(c)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(c.Dir, c.Output), src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// split splits s on commas and discards empty elements.
+func split(s string) []string {
+	var rv []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			rv = append(rv, part)
+		}
+	}
+	return rv
+}
@@ -0,0 +1,37 @@
+// Setgen generates accessors that read and write the fields of structs without reflect.
+//
+// Usage
+//
+// Setgen is intended for go:generate and writes a file into the package directory:
+//	//go:generate go run github.com/nofeaturesonlybugs/set/cmd/setgen -type Person -tags db,json
+//	type Person struct {
+//		ID      int    `db:"id"`
+//		Name    string `db:"name"`
+//		Address struct {
+//			City string `db:"city"`
+//		} `db:"address"`
+//	}
+//
+// The generated file person_setgen.go contains a set.Accessor for Person and a function to
+// register it with a set.Mapper:
+//	mapper := &set.Mapper{Tags: []string{"db", "json"}, Join: "_"}
+//	if err := RegisterPersonAccessor(mapper); err != nil {
+//		// The accessor was generated with different rules than the Mapper.
+//	}
+//	bound, err := mapper.Bind(&person) // BoundMapping now uses the accessor.
+//
+// Mapper Rules
+//
+// Keys are generated with the same rules as set.Mapper.  The flags -tags, -join, -transform,
+// -elevated, and -scalar correspond to the Mapper fields Tags, Join, Transform, Elevated, and
+// TreatAsScalar; other Mapper fields are assumed to be zero.  Type names given to -elevated and
+// -scalar are written as Timestamps, sql.NullString, or database/sql.NullString.  The values of
+// -transform are camel, kebab, lower, screaming, and snake.
+//
+// Fields that are elements of arrays or slices, and fields reached through pointers to
+// unexported types of other packages, are not included and are accessed with reflect by
+// the BoundMapping.  Set only handles values that have exactly the type of a field whose type
+// is a built-in scalar; other values are coerced with reflect as usual.
+//
+// Setgen loads packages from source with go/types and does not require network access.
+package main
@@ -0,0 +1,29 @@
+package rules
+
+import "database/sql"
+
+type inner struct {
+	Hidden string
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+type Audit struct {
+	By string
+}
+
+type T struct {
+	A   string `db:",omitempty"`
+	B   string `db:"-"`
+	C   string `json:"c" db:"see"`
+	Dup string `db:"A"`
+	inner
+	Audit
+	Null sql.NullInt64
+	Node *node
+	Vec  [2]int
+	Ch   chan int
+}
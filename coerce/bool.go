@@ -53,7 +53,7 @@ func Bool(v interface{}) (bool, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -80,7 +80,7 @@ func Bool(v interface{}) (bool, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -175,6 +175,12 @@ func TestBoolFromSlice(t *testing.T) {
 		"slice": {
 			To: []interface{}{42, "true"}, Expect: true,
 		},
+		"array": {
+			To: [2]string{"false", "true"}, Expect: true,
+		},
+		"empty array": {
+			To: [0]string{}, Expect: false,
+		},
 		"slice kind": {
 			To: []interface{}{42, "true", F32(1)}, Expect: true,
 		},
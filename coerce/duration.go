@@ -0,0 +1,101 @@
+package coerce
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Duration coerces v to time.Duration.
+//
+// Strings are parsed with time.ParseDuration; if that fails and the string is numeric then it is
+// treated as a number.  Numbers are treated as a count of nanoseconds.
+func Duration(v interface{}) (time.Duration, error) {
+	for {
+		switch sw := v.(type) {
+		case nil:
+			return 0, nil
+		case time.Duration:
+			return sw, nil
+		case int:
+			return time.Duration(sw), nil
+		case int8:
+			return time.Duration(sw), nil
+		case int16:
+			return time.Duration(sw), nil
+		case int32:
+			return time.Duration(sw), nil
+		case int64:
+			return time.Duration(sw), nil
+		case uint, uint8, uint16, uint32, uint64:
+			u := reflect.ValueOf(sw).Uint()
+			if UintOverflowsInt(u, 64) {
+				return 0, fmt.Errorf("%w; %v overflows time.Duration", ErrOverflow, sw)
+			}
+			return time.Duration(u), nil
+		case float32:
+			v = float64(sw)
+			continue
+		case float64:
+			if FloatOverflowsInt(sw, 64) {
+				return 0, fmt.Errorf("%w; %v overflows time.Duration", ErrOverflow, sw)
+			}
+			return time.Duration(sw), nil
+		case string:
+			if d, err := time.ParseDuration(sw); err == nil {
+				return d, nil
+			} else if n, err := strconv.ParseInt(sw, 10, 64); err == nil {
+				return time.Duration(n), nil
+			} else if f, err := strconv.ParseFloat(sw, 64); err == nil {
+				v = f
+				continue
+			}
+			return 0, fmt.Errorf("%w; could not parse %v as time.Duration", ErrInvalid, sw)
+		}
+		//
+		// Beyond this point we need reflection.
+		T := reflect.TypeOf(v)
+		//
+		// - T.Kind() is a primitive
+		//		convert to actual primitive and try again
+		// - T.Kind() is a pointer
+		//		dereference pointer and try again
+		// - T.Kind() is a slice or array
+		//		pick last element and try again
+		switch T.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v = reflect.ValueOf(v).Int()
+			continue
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v = reflect.ValueOf(v).Uint()
+			continue
+		case reflect.Float32, reflect.Float64:
+			v = reflect.ValueOf(v).Float()
+			continue
+		case reflect.String:
+			v = reflect.ValueOf(v).Convert(TypeString).Interface()
+			continue
+
+		case reflect.Ptr:
+			rv := reflect.ValueOf(v)
+			for ; rv.Kind() == reflect.Ptr; rv = rv.Elem() {
+				if rv.IsNil() {
+					return 0, nil
+				}
+			}
+			v = rv.Interface()
+			continue
+
+		case reflect.Slice, reflect.Array:
+			rv := reflect.ValueOf(v)
+			if n := rv.Len(); n > 0 {
+				v = rv.Index(n - 1).Interface()
+				continue
+			}
+			return 0, nil
+		}
+		//
+		return 0, fmt.Errorf("%w; coerce %v to time.Duration", ErrUnsupported, v)
+	}
+}
@@ -0,0 +1,119 @@
+package coerce_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set/coerce"
+)
+
+// DurationTest is the struct used to build up table driven tests for time.Duration.
+type DurationTest struct {
+	To     interface{}
+	Error  error
+	Expect time.Duration
+}
+
+// DurationTests is a table of DurationTest.
+type DurationTests map[string]*DurationTest
+
+// Run iterates the DurationTests and runs each one.
+func (tests DurationTests) Run(t *testing.T) {
+	for name, test := range tests {
+		t.Run("duration "+name, func(t *testing.T) {
+			chk := assert.New(t)
+			d, err := coerce.Duration(test.To)
+			chk.True(errors.Is(err, test.Error), "%v", err)
+			chk.Equal(test.Expect, d)
+		})
+	}
+}
+
+func TestDuration(t *testing.T) {
+	d := time.Hour + 30*time.Minute
+	tests := DurationTests{
+		"nil": {
+			To: nil, Expect: 0,
+		},
+		"time.Duration": {
+			To: d, Expect: d,
+		},
+		"*time.Duration": {
+			To: &d, Expect: d,
+		},
+		"nil *time.Duration": {
+			To: (*time.Duration)(nil), Expect: 0,
+		},
+		"[]time.Duration": {
+			To: []time.Duration{0, d}, Expect: d,
+		},
+		"empty []time.Duration": {
+			To: []time.Duration{}, Expect: 0,
+		},
+		"1h30m": {
+			To: "1h30m", Expect: d,
+		},
+		"S": {
+			To: S("1h30m"), Expect: d,
+		},
+		"numeric string": {
+			To: "5400000000000", Expect: d,
+		},
+		"float string": {
+			To: "1.5e3", Expect: 1500,
+		},
+		"invalid": {
+			To: "forever", Error: coerce.ErrInvalid,
+		},
+		"int": {
+			To: int(d), Expect: d,
+		},
+		"int8": {
+			To: int8(8), Expect: 8,
+		},
+		"int16": {
+			To: int16(16), Expect: 16,
+		},
+		"int32": {
+			To: int32(32), Expect: 32,
+		},
+		"int64": {
+			To: int64(d), Expect: d,
+		},
+		"I64": {
+			To: I64(d), Expect: d,
+		},
+		"uint": {
+			To: uint(10), Expect: 10,
+		},
+		"U8": {
+			To: U8(10), Expect: 10,
+		},
+		"uint64 overflow": {
+			To: uint64(math.MaxUint64), Error: coerce.ErrOverflow,
+		},
+		"float32": {
+			To: float32(2), Expect: 2,
+		},
+		"float64": {
+			To: float64(d), Expect: d,
+		},
+		"F32": {
+			To: F32(2), Expect: 2,
+		},
+		"float64 overflow": {
+			To: math.MaxFloat64, Error: coerce.ErrOverflow,
+		},
+		"bool": {
+			To: true, Error: coerce.ErrUnsupported,
+		},
+		"map": {
+			To: map[string]string{}, Error: coerce.ErrUnsupported,
+		},
+	}
+	tests.Run(t)
+}
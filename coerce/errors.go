@@ -11,6 +11,10 @@ var (
 	// ErrInvalid occurs when an attempted coercion is invalid.
 	ErrInvalid = fmt.Errorf("coerce: invalid")
 
+	// ErrLossy occurs when a coercion would lose information and strict checking is requested;
+	// see CheckStrict.
+	ErrLossy = fmt.Errorf("coerce: lossy")
+
 	// ErrOverflow occurs when an attempted coercion overflows the destination.
 	ErrOverflow = fmt.Errorf("coerce: overflow")
 
@@ -69,7 +69,7 @@ func Float32(v interface{}) (float32, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -103,7 +103,7 @@ func Float32(v interface{}) (float32, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -173,7 +173,7 @@ func Float64(v interface{}) (float64, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -203,7 +203,7 @@ func Float64(v interface{}) (float64, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -202,6 +202,7 @@ func TestFloatFromSlice(t *testing.T) {
 		"nil":        {To: []string(nil), Expect32: 0, Expect64: 0},
 		"slice":      {To: []interface{}{"42", "78"}, Expect32: 78, Expect64: 78},
 		"slice kind": {To: []interface{}{"42", "78", S("27")}, Expect32: 27, Expect64: 27},
+		"array":      {To: [2]float64{42, 78}, Expect32: 78, Expect64: 78},
 	}
 	tests.Run(t)
 }
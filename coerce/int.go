@@ -97,7 +97,7 @@ func Int(v interface{}) (int, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -137,7 +137,7 @@ func Int(v interface{}) (int, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -233,7 +233,7 @@ func Int8(v interface{}) (int8, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -273,7 +273,7 @@ func Int8(v interface{}) (int8, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -367,7 +367,7 @@ func Int16(v interface{}) (int16, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -407,7 +407,7 @@ func Int16(v interface{}) (int16, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -499,7 +499,7 @@ func Int32(v interface{}) (int32, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -539,7 +539,7 @@ func Int32(v interface{}) (int32, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -618,7 +618,7 @@ func Int64(v interface{}) (int64, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -658,7 +658,7 @@ func Int64(v interface{}) (int64, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -369,6 +369,14 @@ func TestIntFromSlice(t *testing.T) {
 			To:      []interface{}{"42", "78"},
 			Expect8: 78, Expect16: 78, Expect32: 78, Expect64: 78, Expect: 78,
 		},
+		"array": {
+			To:      [2]interface{}{"42", "78"},
+			Expect8: 78, Expect16: 78, Expect32: 78, Expect64: 78, Expect: 78,
+		},
+		"empty array": {
+			To:      [0]int{},
+			Expect8: 0, Expect16: 0, Expect32: 0, Expect64: 0, Expect: 0,
+		},
 		"slice kind": {
 			To:      []interface{}{"42", "78", F32(78)},
 			Expect8: 78, Expect16: 78, Expect32: 78, Expect64: 78, Expect: 78,
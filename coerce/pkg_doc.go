@@ -21,8 +21,8 @@
 //		v is a pointer
 //			// dereference v and try again
 //			v = *v; continue
-//		v is a slice
-//			// try again with last slice element
+//		v is a slice or array
+//			// try again with last element
 //			v = v[len(v)-1]; continue
 //		ErrUnsupported
 //	}
@@ -36,11 +36,29 @@
 // If v is a pointer or any pointer chain it is followed until the final value and the loop
 // restarts with a continue statement.  A nil pointer shortcuts and returns an appropriate zero value.
 //
-// If v is a slice then v is reassigned to the last element and the loop starts again with
-// a continue statement.  An empty slice shortcuts and returns an appropriate zero value.
+// If v is a slice or array then v is reassigned to the last element and the loop starts again with
+// a continue statement.  An empty slice or array shortcuts and returns an appropriate zero value.
 //
 // All other types for v (e.g. chan, map, func, etc) return a zero value and ErrUnsupported.
 //
+// Text Representations
+//
+// String prefers encoding.TextMarshaler and then fmt.Stringer when v is not a primitive type but
+// implements either interface.  This allows types such as net.IP or *big.Int to be coerced to
+// their natural string representations.
+//
+// Time and Duration
+//
+// Time parses strings with the layouts in TimeOptions, falling back to numeric parsing, and treats
+// numbers as a count of TimeOptions.Unit since the Unix epoch.  Duration parses strings with
+// time.ParseDuration and treats numbers as nanoseconds.
+//
+// Strict Coercion
+//
+// The coercion functions are permissive: fractions are truncated, slices contribute their last
+// element, and nil becomes the zero value.  Call CheckStrict before coercing to reject such
+// values with ErrLossy.
+//
 // Overflow
 //
 // During numeric coercions this package checks incoming values against the minimum and maximum value
@@ -0,0 +1,101 @@
+package coerce
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// CheckStrict returns an error wrapping ErrLossy if coercing v to a value of kind k would lose
+// information.  The coercion functions in this package are permissive; call CheckStrict before
+// them when lossy coercions are unacceptable.
+//
+// The following are lossy:
+//	+ nil or a nil pointer; the coercion functions return the zero value.
+//	+ A slice without exactly one element; the coercion functions use the last element.
+//	+ A bool to a number or a number to a bool.
+//	+ A number or numeric string with a fractional part to an integer kind.
+//	+ Kinds that are not primitives (maps, structs, channels, etc) unless k is reflect.String
+//	  and v implements encoding.TextMarshaler or fmt.Stringer.
+//
+// Overflow is not checked here; the coercion functions already return ErrOverflow.  When k is not
+// a primitive kind only the nil and slice rules are applied.
+func CheckStrict(v interface{}, k reflect.Kind) error {
+	rv := reflect.ValueOf(v)
+	for {
+		switch rv.Kind() {
+		case reflect.Invalid:
+			return fmt.Errorf("%w; nil to %v", ErrLossy, k)
+		case reflect.Ptr, reflect.Interface:
+			if rv.IsNil() {
+				return fmt.Errorf("%w; nil %v to %v", ErrLossy, rv.Type(), k)
+			}
+		}
+		if k == reflect.String && rv.CanInterface() {
+			switch rv.Interface().(type) {
+			case encoding.TextMarshaler, fmt.Stringer:
+				return nil
+			}
+		}
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			rv = rv.Elem()
+			continue
+		case reflect.Slice, reflect.Array:
+			if n := rv.Len(); n != 1 {
+				return fmt.Errorf("%w; %v with %v elements to %v", ErrLossy, rv.Type(), n, k)
+			}
+			rv = rv.Index(0)
+			continue
+		}
+		break
+	}
+	//
+	isBool := k == reflect.Bool
+	isInt := k == reflect.Int || k == reflect.Int8 || k == reflect.Int16 || k == reflect.Int32 || k == reflect.Int64 ||
+		k == reflect.Uint || k == reflect.Uint8 || k == reflect.Uint16 || k == reflect.Uint32 || k == reflect.Uint64
+	isNumber := isInt || k == reflect.Float32 || k == reflect.Float64
+	if !isBool && !isNumber && k != reflect.String {
+		return nil
+	}
+	//
+	switch rv.Kind() {
+	case reflect.Bool:
+		if isNumber {
+			return fmt.Errorf("%w; bool to %v", ErrLossy, k)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if isBool {
+			return fmt.Errorf("%w; %v to bool", ErrLossy, rv.Kind())
+		}
+	case reflect.Float32, reflect.Float64:
+		if isBool {
+			return fmt.Errorf("%w; %v to bool", ErrLossy, rv.Kind())
+		} else if f := rv.Float(); isInt && f != math.Trunc(f) {
+			return fmt.Errorf("%w; %v to %v", ErrLossy, f, k)
+		}
+	case reflect.String:
+		// Strings that do not parse are left for the coercion functions to report as ErrInvalid.
+		s := rv.String()
+		if !isNumber {
+			return nil
+		} else if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return nil
+		} else if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return nil
+		} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+			if isInt && f != math.Trunc(f) {
+				return fmt.Errorf("%w; %q to %v", ErrLossy, s, k)
+			}
+			return nil
+		} else if _, err := strconv.ParseBool(s); err == nil {
+			return fmt.Errorf("%w; %q to %v", ErrLossy, s, k)
+		}
+	default:
+		return fmt.Errorf("%w; %v to %v", ErrLossy, rv.Type(), k)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package coerce_test
+
+import (
+	"errors"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set/coerce"
+)
+
+// StrictTest is the struct used to build up table driven tests for CheckStrict.
+type StrictTest struct {
+	From  interface{}
+	Kind  reflect.Kind
+	Error error
+}
+
+// StrictTests is a table of StrictTest.
+type StrictTests map[string]*StrictTest
+
+// Run iterates the StrictTests and runs each one.
+func (tests StrictTests) Run(t *testing.T) {
+	for name, test := range tests {
+		t.Run("strict "+name, func(t *testing.T) {
+			chk := assert.New(t)
+			err := coerce.CheckStrict(test.From, test.Kind)
+			chk.True(errors.Is(err, test.Error), "%v", err)
+		})
+	}
+}
+
+func TestCheckStrict(t *testing.T) {
+	one := 1
+	var nilInt *int
+	var nilBig *big.Int
+	tests := StrictTests{
+		// nil
+		"nil":          {From: nil, Kind: reflect.Int, Error: coerce.ErrLossy},
+		"nil pointer":  {From: nilInt, Kind: reflect.String, Error: coerce.ErrLossy},
+		"nil Stringer": {From: nilBig, Kind: reflect.String, Error: coerce.ErrLossy},
+		"nil to time":  {From: nil, Kind: reflect.Struct, Error: coerce.ErrLossy},
+		// pointers and slices
+		"pointer":            {From: &one, Kind: reflect.Int},
+		"one element":        {From: []int{1}, Kind: reflect.Int},
+		"one interface":      {From: []interface{}{&one}, Kind: reflect.Float64},
+		"array":              {From: [1]string{"a"}, Kind: reflect.String},
+		"empty slice":        {From: []int{}, Kind: reflect.Int, Error: coerce.ErrLossy},
+		"many elements":      {From: []int{1, 2}, Kind: reflect.Int, Error: coerce.ErrLossy},
+		"many elements time": {From: []string{"a", "b"}, Kind: reflect.Struct, Error: coerce.ErrLossy},
+		"bytes":              {From: []byte("ab"), Kind: reflect.String, Error: coerce.ErrLossy},
+		// bool crossovers
+		"bool to bool":       {From: true, Kind: reflect.Bool},
+		"bool to string":     {From: true, Kind: reflect.String},
+		"bool to int":        {From: true, Kind: reflect.Int, Error: coerce.ErrLossy},
+		"bool to float":      {From: false, Kind: reflect.Float32, Error: coerce.ErrLossy},
+		"int to bool":        {From: 1, Kind: reflect.Bool, Error: coerce.ErrLossy},
+		"uint to bool":       {From: U8(1), Kind: reflect.Bool, Error: coerce.ErrLossy},
+		"float to bool":      {From: 1.0, Kind: reflect.Bool, Error: coerce.ErrLossy},
+		"string to bool":     {From: "true", Kind: reflect.Bool},
+		"bool string to int": {From: "true", Kind: reflect.Int, Error: coerce.ErrLossy},
+		// fractions
+		"whole float to int":       {From: 3.0, Kind: reflect.Int},
+		"float to int":             {From: 3.9, Kind: reflect.Int, Error: coerce.ErrLossy},
+		"float32 to uint":          {From: float32(0.5), Kind: reflect.Uint8, Error: coerce.ErrLossy},
+		"F64 to int64":             {From: F64(-1.25), Kind: reflect.Int64, Error: coerce.ErrLossy},
+		"float to float":           {From: 3.9, Kind: reflect.Float32},
+		"float to string":          {From: 3.9, Kind: reflect.String},
+		"int string to int":        {From: "42", Kind: reflect.Int},
+		"uint string to uint":      {From: "18446744073709551615", Kind: reflect.Uint64},
+		"whole string to int":      {From: "42.0", Kind: reflect.Int},
+		"fraction string to int":   {From: "3.9", Kind: reflect.Int, Error: coerce.ErrLossy},
+		"fraction string to float": {From: S("3.9"), Kind: reflect.Float64},
+		"invalid string to int":    {From: "abc", Kind: reflect.Int},
+		// unsupported kinds
+		"map to int":       {From: map[string]int{}, Kind: reflect.Int, Error: coerce.ErrLossy},
+		"struct to string": {From: struct{}{}, Kind: reflect.String, Error: coerce.ErrLossy},
+		"Stringer":         {From: big.NewInt(5), Kind: reflect.String},
+		"TextMarshaler":    {From: net.IPv4(10, 0, 0, 1), Kind: reflect.String},
+		"Stringer to int":  {From: big.NewInt(5), Kind: reflect.Int, Error: coerce.ErrLossy},
+		"struct to struct": {From: struct{}{}, Kind: reflect.Struct},
+		"string to struct": {From: "2021-03-04", Kind: reflect.Struct},
+	}
+	tests.Run(t)
+}
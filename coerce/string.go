@@ -1,12 +1,16 @@
 package coerce
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
 	"strconv"
 )
 
 // String coerces v to string.
+//
+// If v is not a primitive but implements encoding.TextMarshaler or fmt.Stringer then
+// MarshalText or String is used, in that order of preference.
 func String(v interface{}) (string, error) {
 	for {
 		switch sw := v.(type) {
@@ -42,6 +46,21 @@ func String(v interface{}) (string, error) {
 			return sw, nil
 		}
 		//
+		// Types that know how to represent themselves as text; a nil pointer
+		// skips these and is handled below.
+		if !isNilPtr(v) {
+			switch sw := v.(type) {
+			case encoding.TextMarshaler:
+				b, err := sw.MarshalText()
+				if err != nil {
+					return "", fmt.Errorf("%w; %v", ErrInvalid, err.Error())
+				}
+				return string(b), nil
+			case fmt.Stringer:
+				return sw.String(), nil
+			}
+		}
+		//
 		// Beyond this point we need reflection.
 		T := reflect.TypeOf(v)
 		//
@@ -49,7 +68,7 @@ func String(v interface{}) (string, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -77,7 +96,7 @@ func String(v interface{}) (string, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -89,3 +108,9 @@ func String(v interface{}) (string, error) {
 		return "", fmt.Errorf("%w; coerce %v to string", ErrUnsupported, v)
 	}
 }
+
+// isNilPtr returns true if v is a nil pointer.
+func isNilPtr(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
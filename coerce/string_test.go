@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -14,6 +17,30 @@ import (
 // S is a new type derived from string.
 type S string
 
+// Level is a type implementing fmt.Stringer.
+type Level int
+
+func (l Level) String() string {
+	return [...]string{"debug", "info", "warn"}[l]
+}
+
+// Text is a type implementing encoding.TextMarshaler; it also implements fmt.Stringer to
+// ensure MarshalText is preferred.
+type Text struct {
+	Value string
+}
+
+func (t *Text) MarshalText() ([]byte, error) {
+	if t.Value == "" {
+		return nil, fmt.Errorf("empty text")
+	}
+	return []byte("text:" + t.Value), nil
+}
+
+func (t *Text) String() string {
+	return "string:" + t.Value
+}
+
 // StringTest is the struct used to build up table driven tests for strings.
 type StringTest struct {
 	To     interface{}
@@ -177,6 +204,9 @@ func TestStringFromSlice(t *testing.T) {
 		"slice": {
 			To: []interface{}{"42", "78"}, Expect: "78",
 		},
+		"array": {
+			To: [2]interface{}{"42", "78"}, Expect: "78",
+		},
 		"slice kind": {
 			To: []interface{}{"42", "78", II(78)}, Expect: "78",
 		},
@@ -241,3 +271,40 @@ func TestStringFromString(t *testing.T) {
 	}
 	tests.Run(t)
 }
+
+func TestStringFromMarshalers(t *testing.T) {
+	var nilText *Text
+	tests := StringTests{
+		"Level": {
+			To: Level(1), Expect: "info",
+		},
+		"*Level": {
+			To: func() *Level { l := Level(2); return &l }(), Expect: "warn",
+		},
+		"*Text": {
+			To: &Text{Value: "hi"}, Expect: "text:hi",
+		},
+		"*Text error": {
+			To: &Text{}, Expect: "", Error: coerce.ErrInvalid,
+		},
+		"*Text nil": {
+			To: nilText, Expect: "",
+		},
+		"net.IP": {
+			To: net.IPv4(10, 0, 0, 1), Expect: "10.0.0.1",
+		},
+		"*big.Int": {
+			To: big.NewInt(1234567890), Expect: "1234567890",
+		},
+		"time.Duration": {
+			To: time.Hour, Expect: "1h0m0s",
+		},
+		"*time.Duration": {
+			To: func() *time.Duration { d := 90 * time.Second; return &d }(), Expect: "1m30s",
+		},
+		"Level slice": {
+			To: []Level{0, 2}, Expect: "warn",
+		},
+	}
+	tests.Run(t)
+}
@@ -0,0 +1,169 @@
+package coerce
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// TimeOptions controls how values are coerced to time.Time.
+type TimeOptions struct {
+	// Layouts are tried in order when parsing a string; the first successful parse is used.
+	Layouts []string
+
+	// Location is used for layouts that do not contain time zone information.  If nil
+	// then time.UTC is used.
+	Location *time.Location
+
+	// Unit is the unit of numeric values relative to the Unix epoch; for example time.Second
+	// or time.Millisecond.  If zero then time.Second is used.
+	Unit time.Duration
+}
+
+// DefaultTimeOptions are the TimeOptions used by Time.
+//
+// Numeric values are interpreted as Unix seconds and strings are parsed with RFC3339 followed
+// by a list of common fallbacks.
+var DefaultTimeOptions = TimeOptions{
+	Layouts: []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999 -0700 MST",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02",
+		time.RFC1123Z,
+		time.RFC1123,
+		time.RFC850,
+		time.RubyDate,
+		time.UnixDate,
+		time.ANSIC,
+	},
+	Location: time.UTC,
+	Unit:     time.Second,
+}
+
+// Time coerces v to time.Time using DefaultTimeOptions.
+func Time(v interface{}) (time.Time, error) {
+	return DefaultTimeOptions.Time(v)
+}
+
+// Time coerces v to time.Time.
+//
+// Strings are parsed with each of the Layouts; if none succeed and the string is numeric then it
+// is treated as a number.  Numbers are treated as a count of Unit since the Unix epoch.
+func (o TimeOptions) Time(v interface{}) (time.Time, error) {
+	for {
+		switch sw := v.(type) {
+		case nil:
+			return time.Time{}, nil
+		case time.Time:
+			return sw, nil
+		case int:
+			return o.unix(int64(sw), 0), nil
+		case int8:
+			return o.unix(int64(sw), 0), nil
+		case int16:
+			return o.unix(int64(sw), 0), nil
+		case int32:
+			return o.unix(int64(sw), 0), nil
+		case int64:
+			return o.unix(sw, 0), nil
+		case uint, uint8, uint16, uint32, uint64:
+			u := reflect.ValueOf(sw).Uint()
+			if UintOverflowsInt(u, 64) {
+				return time.Time{}, fmt.Errorf("%w; %v overflows time.Time", ErrOverflow, sw)
+			}
+			return o.unix(int64(u), 0), nil
+		case float32:
+			v = float64(sw)
+			continue
+		case float64:
+			whole, frac := math.Modf(sw)
+			if FloatOverflowsInt(whole, 64) {
+				return time.Time{}, fmt.Errorf("%w; %v overflows time.Time", ErrOverflow, sw)
+			}
+			return o.unix(int64(whole), frac), nil
+		case string:
+			loc := o.Location
+			if loc == nil {
+				loc = time.UTC
+			}
+			for _, layout := range o.Layouts {
+				if t, err := time.ParseInLocation(layout, sw, loc); err == nil {
+					return t, nil
+				}
+			}
+			if n, err := strconv.ParseInt(sw, 10, 64); err == nil {
+				v = n
+				continue
+			} else if f, err := strconv.ParseFloat(sw, 64); err == nil {
+				v = f
+				continue
+			}
+			return time.Time{}, fmt.Errorf("%w; could not parse %v as time.Time", ErrInvalid, sw)
+		}
+		//
+		// Beyond this point we need reflection.
+		T := reflect.TypeOf(v)
+		//
+		// - T.Kind() is a primitive
+		//		convert to actual primitive and try again
+		// - T.Kind() is a pointer
+		//		dereference pointer and try again
+		// - T.Kind() is a slice or array
+		//		pick last element and try again
+		switch T.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v = reflect.ValueOf(v).Int()
+			continue
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v = reflect.ValueOf(v).Uint()
+			continue
+		case reflect.Float32, reflect.Float64:
+			v = reflect.ValueOf(v).Float()
+			continue
+		case reflect.String:
+			v = reflect.ValueOf(v).Convert(TypeString).Interface()
+			continue
+
+		case reflect.Ptr:
+			rv := reflect.ValueOf(v)
+			for ; rv.Kind() == reflect.Ptr; rv = rv.Elem() {
+				if rv.IsNil() {
+					return time.Time{}, nil
+				}
+			}
+			v = rv.Interface()
+			continue
+
+		case reflect.Slice, reflect.Array:
+			rv := reflect.ValueOf(v)
+			if n := rv.Len(); n > 0 {
+				v = rv.Index(n - 1).Interface()
+				continue
+			}
+			return time.Time{}, nil
+		}
+		//
+		return time.Time{}, fmt.Errorf("%w; coerce %v to time.Time", ErrUnsupported, v)
+	}
+}
+
+// unix returns the time.Time for whole+frac units since the Unix epoch.
+func (o TimeOptions) unix(whole int64, frac float64) time.Time {
+	unit := o.Unit
+	if unit <= 0 {
+		unit = time.Second
+	}
+	// Split whole into seconds and remaining units to avoid overflowing nanoseconds.
+	perSecond := int64(time.Second / unit)
+	if perSecond == 0 {
+		// unit is larger than a second; e.g. time.Minute.
+		return time.Unix(whole*int64(unit/time.Second), int64(frac*float64(unit)))
+	}
+	sec, rem := whole/perSecond, whole%perSecond
+	return time.Unix(sec, rem*int64(unit)+int64(frac*float64(unit)))
+}
@@ -0,0 +1,166 @@
+package coerce_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set/coerce"
+)
+
+// TimeTest is the struct used to build up table driven tests for time.Time.
+type TimeTest struct {
+	To      interface{}
+	Options *coerce.TimeOptions
+	Error   error
+	Expect  time.Time
+}
+
+// TimeTests is a table of TimeTest.
+type TimeTests map[string]*TimeTest
+
+// Run iterates the TimeTests and runs each one.
+func (tests TimeTests) Run(t *testing.T) {
+	for name, test := range tests {
+		t.Run("time "+name, func(t *testing.T) {
+			chk := assert.New(t)
+			var tm time.Time
+			var err error
+			if test.Options != nil {
+				tm, err = test.Options.Time(test.To)
+			} else {
+				tm, err = coerce.Time(test.To)
+			}
+			chk.True(errors.Is(err, test.Error), "%v", err)
+			chk.True(test.Expect.Equal(tm), "expected %v got %v", test.Expect, tm)
+		})
+	}
+}
+
+func TestTime(t *testing.T) {
+	date := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	nanos := time.Date(2021, 3, 4, 5, 6, 7, 123456789, time.UTC)
+	unix := date.Unix()
+	tests := TimeTests{
+		"nil": {
+			To: nil, Expect: time.Time{},
+		},
+		"time.Time": {
+			To: date, Expect: date,
+		},
+		"*time.Time": {
+			To: &date, Expect: date,
+		},
+		"nil *time.Time": {
+			To: (*time.Time)(nil), Expect: time.Time{},
+		},
+		"[]time.Time": {
+			To: []time.Time{{}, date}, Expect: date,
+		},
+		"empty []time.Time": {
+			To: []time.Time{}, Expect: time.Time{},
+		},
+		"RFC3339": {
+			To: "2021-03-04T05:06:07Z", Expect: date,
+		},
+		"RFC3339 offset": {
+			To: "2021-03-04T00:06:07-05:00", Expect: date,
+		},
+		"RFC3339Nano": {
+			To: "2021-03-04T05:06:07.123456789Z", Expect: nanos,
+		},
+		"no zone": {
+			To: "2021-03-04T05:06:07", Expect: date,
+		},
+		"space separated": {
+			To: "2021-03-04 05:06:07", Expect: date,
+		},
+		"date only": {
+			To: "2021-03-04", Expect: time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+		"time.String": {
+			To: nanos.String(), Expect: nanos,
+		},
+		"RFC1123": {
+			To: date.Format(time.RFC1123), Expect: date,
+		},
+		"S": {
+			To: S("2021-03-04T05:06:07Z"), Expect: date,
+		},
+		"int": {
+			To: int(unix), Expect: date,
+		},
+		"int8": {
+			To: int8(1), Expect: time.Unix(1, 0),
+		},
+		"int16": {
+			To: int16(1), Expect: time.Unix(1, 0),
+		},
+		"int32": {
+			To: int32(unix), Expect: date,
+		},
+		"int64": {
+			To: unix, Expect: date,
+		},
+		"I64": {
+			To: I64(unix), Expect: date,
+		},
+		"uint64": {
+			To: uint64(unix), Expect: date,
+		},
+		"U64": {
+			To: U64(unix), Expect: date,
+		},
+		"uint64 overflow": {
+			To: uint64(math.MaxUint64), Error: coerce.ErrOverflow,
+		},
+		"float64": {
+			To: float64(unix) + 0.5, Expect: date.Add(500 * time.Millisecond),
+		},
+		"float32": {
+			To: float32(1.5), Expect: time.Unix(1, 500000000),
+		},
+		"F64": {
+			To: F64(unix), Expect: date,
+		},
+		"float64 overflow": {
+			To: math.MaxFloat64, Error: coerce.ErrOverflow,
+		},
+		"numeric string": {
+			To: "1614834367", Expect: date,
+		},
+		"float string": {
+			To: "1614834367.5", Expect: date.Add(500 * time.Millisecond),
+		},
+		"invalid": {
+			To: "yesterday", Error: coerce.ErrInvalid,
+		},
+		"bool": {
+			To: true, Error: coerce.ErrUnsupported,
+		},
+		"map": {
+			To: map[string]string{}, Error: coerce.ErrUnsupported,
+		},
+		"millis": {
+			To: unix*1000 + 123, Options: &coerce.TimeOptions{Unit: time.Millisecond}, Expect: date.Add(123 * time.Millisecond),
+		},
+		"millis float": {
+			To: float64(unix*1000) + 0.5, Options: &coerce.TimeOptions{Unit: time.Millisecond}, Expect: date.Add(500 * time.Microsecond),
+		},
+		"minutes": {
+			To: 2, Options: &coerce.TimeOptions{Unit: time.Minute}, Expect: time.Unix(120, 0),
+		},
+		"custom layout": {
+			To: "04/03/2021 05:06", Options: &coerce.TimeOptions{Layouts: []string{"02/01/2006 15:04"}}, Expect: date.Add(-7 * time.Second),
+		},
+		"location": {
+			To:      "2021-03-04 05:06:07",
+			Options: &coerce.TimeOptions{Layouts: []string{"2006-01-02 15:04:05"}, Location: time.FixedZone("X", 3600)},
+			Expect:  date.Add(-time.Hour),
+		},
+	}
+	tests.Run(t)
+}
@@ -95,7 +95,7 @@ func Uint(v interface{}) (uint, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -135,7 +135,7 @@ func Uint(v interface{}) (uint, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -231,7 +231,7 @@ func Uint8(v interface{}) (uint8, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -271,7 +271,7 @@ func Uint8(v interface{}) (uint8, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -364,7 +364,7 @@ func Uint16(v interface{}) (uint16, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -404,7 +404,7 @@ func Uint16(v interface{}) (uint16, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -494,7 +494,7 @@ func Uint32(v interface{}) (uint32, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -534,7 +534,7 @@ func Uint32(v interface{}) (uint32, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
@@ -618,7 +618,7 @@ func Uint64(v interface{}) (uint64, error) {
 		//		convert to actual primitive and try again
 		// - T.Kind() is a pointer
 		//		dereference pointer and try again
-		// - T.Kind() is a slice
+		// - T.Kind() is a slice or array
 		//		pick last element and try again
 		switch T.Kind() {
 		case reflect.Bool:
@@ -658,7 +658,7 @@ func Uint64(v interface{}) (uint64, error) {
 			v = rv.Interface()
 			continue
 
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			rv := reflect.ValueOf(v)
 			if n := rv.Len(); n > 0 {
 				v = rv.Index(n - 1).Interface()
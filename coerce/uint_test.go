@@ -410,6 +410,10 @@ func TestUintFromSlice(t *testing.T) {
 			To:      []interface{}{10, "42", "78"},
 			Expect8: 78, Expect16: 78, Expect32: 78, Expect64: 78, Expect: 78,
 		},
+		"array": {
+			To:      [3]interface{}{10, "42", "78"},
+			Expect8: 78, Expect16: 78, Expect32: 78, Expect64: 78, Expect: 78,
+		},
 		"slice kind": {
 			To:      []interface{}{10, "42", "78", S("78")},
 			Expect8: 78, Expect16: 78, Expect32: 78, Expect64: 78, Expect: 78,
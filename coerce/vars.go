@@ -1,6 +1,9 @@
 package coerce
 
-import "reflect"
+import (
+	"reflect"
+	"time"
+)
 
 // Calculate and cache some common reflect.Type values needed
 // during type coercion.
@@ -11,4 +14,7 @@ var (
 	TypeInt64   = reflect.TypeOf(int64(0))
 	TypeUint64  = reflect.TypeOf(uint64(0))
 	TypeString  = reflect.TypeOf("")
+
+	TypeDuration = reflect.TypeOf(time.Duration(0))
+	TypeTime     = reflect.TypeOf(time.Time{})
 )
@@ -0,0 +1,128 @@
+package set
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// ConverterFunc converts v into a value for the destination type it was registered for.
+//
+// The returned value should be assignable or convertible to the destination type.  A nil
+// return value with a nil error sets the destination to its zero value.
+type ConverterFunc func(v interface{}) (interface{}, error)
+
+// converterKey is the key used to store a ConverterFunc in a ConverterRegistry.
+type converterKey struct {
+	From reflect.Type
+	To   reflect.Type
+}
+
+// ConverterRegistry is a collection of ConverterFunc keyed by source and destination type.
+//
+// Value.To consults the global Converters registry before performing any of its built-in
+// coercion logic.  A Mapper may also be given its own registry; BoundMapping and PreparedMapping
+// instances created by that Mapper consult the Mapper's registry before the global one.
+//
+// ConverterRegistry is goroutine safe.
+type ConverterRegistry struct {
+	// size is the number of registered converters; when it is zero lookups can be skipped entirely.
+	size      int64
+	functions sync.Map
+}
+
+// Converters is the global ConverterRegistry.
+var Converters = NewConverterRegistry()
+
+// NewConverterRegistry creates a new ConverterRegistry.
+func NewConverterRegistry() *ConverterRegistry {
+	return &ConverterRegistry{}
+}
+
+// Register adds fn as the converter from the type of from to the type of to.  If a converter
+// is already registered for the pair it is replaced.  A nil fn removes the converter.
+//
+// As a convenience from and to can be instances of reflect.Type; this allows interface types
+// to be registered.
+//	registry.Register("", uuid.UUID{}, func(v interface{}) (interface{}, error) {
+//		return uuid.Parse(v.(string))
+//	})
+func (r *ConverterRegistry) Register(from, to interface{}, fn ConverterFunc) {
+	key := converterKey{From: converterType(from), To: converterType(to)}
+	if fn == nil {
+		if _, loaded := r.functions.LoadAndDelete(key); loaded {
+			atomic.AddInt64(&r.size, -1)
+		}
+		return
+	}
+	if _, loaded := r.functions.LoadOrStore(key, fn); loaded {
+		r.functions.Store(key, fn)
+		return
+	}
+	atomic.AddInt64(&r.size, 1)
+}
+
+// Lookup returns the converter registered for the from and to types.
+func (r *ConverterRegistry) Lookup(from, to reflect.Type) (ConverterFunc, bool) {
+	if r == nil || atomic.LoadInt64(&r.size) == 0 {
+		return nil, false
+	}
+	if fn, ok := r.functions.Load(converterKey{From: from, To: to}); ok {
+		return fn.(ConverterFunc), true
+	}
+	return nil, false
+}
+
+// converterType returns T as a reflect.Type.
+func converterType(T interface{}) reflect.Type {
+	if rt, ok := T.(reflect.Type); ok {
+		return rt
+	}
+	return reflect.TypeOf(T)
+}
+
+// convertsSelf returns true if local or the global Converters registry has a converter from the
+// type of value to the same type; local may be nil.  The fast paths in BoundMapping.Set and
+// PreparedMapping.Set only handle values with exactly the type of the field and are skipped
+// when it returns true.
+func convertsSelf(local *ConverterRegistry, value interface{}) bool {
+	T := reflect.TypeOf(value)
+	if _, ok := local.Lookup(T, T); ok {
+		return true
+	}
+	_, ok := Converters.Lookup(T, T)
+	return ok
+}
+
+// convert attempts to assign arg into dst with a converter from local or the global Converters
+// registry; local may be nil and is searched first.
+//
+// handled is true if a converter was found and called.
+func convert(dst Value, arg interface{}, local *ConverterRegistry) (handled bool, err error) {
+	from := reflect.TypeOf(arg)
+	fn, handled := local.Lookup(from, dst.Type)
+	if !handled {
+		if fn, handled = Converters.Lookup(from, dst.Type); !handled {
+			return false, nil
+		}
+	}
+	//
+	rv, err := fn(arg)
+	if err != nil {
+		dst.WriteValue.Set(reflect.Zero(dst.Type))
+		return true, err
+	} else if rv == nil {
+		dst.WriteValue.Set(reflect.Zero(dst.Type))
+		return true, nil
+	}
+	RV := reflect.ValueOf(rv)
+	if T := RV.Type(); T.AssignableTo(dst.Type) {
+		dst.WriteValue.Set(RV)
+	} else if T.ConvertibleTo(dst.Type) {
+		dst.WriteValue.Set(RV.Convert(dst.Type))
+	} else {
+		dst.WriteValue.Set(reflect.Zero(dst.Type))
+		return true, pkgerr{Err: ErrUnsupported, CallSite: "Value.To", Context: "converter from " + from.String() + " to " + dst.Type.String() + " returned " + T.String()}
+	}
+	return true, nil
+}
@@ -0,0 +1,48 @@
+package set_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func ExampleConverterRegistry() {
+	// Color is a type the set package does not know how to create from a string.
+	type Color struct {
+		R, G, B uint8
+	}
+	type Widget struct {
+		Name  string
+		Color Color
+	}
+
+	// Converters are registered per (source type, destination type) and can be scoped
+	// to a Mapper or registered globally in set.Converters.
+	registry := set.NewConverterRegistry()
+	registry.Register("", Color{}, func(v interface{}) (interface{}, error) {
+		switch strings.ToLower(v.(string)) {
+		case "red":
+			return Color{R: 255}, nil
+		case "green":
+			return Color{G: 255}, nil
+		}
+		return nil, fmt.Errorf("unknown color %v", v)
+	})
+	mapper := &set.Mapper{
+		TreatAsScalar: set.NewTypeList(Color{}),
+		Converters:    registry,
+	}
+
+	var w Widget
+	b, _ := mapper.Bind(&w)
+	_ = b.Set("Name", "Sprocket") // error ignored for brevity
+	_ = b.Set("Color", "Green")   // error ignored for brevity
+	fmt.Printf("%v %+v\n", w.Name, w.Color)
+
+	err := b.Set("Color", "Purple")
+	fmt.Println(err)
+
+	// Output: Sprocket {R:0 G:255 B:0}
+	// set: BoundMapping.Set: field [Color] key [Color] type set_test.Color: unknown color Purple
+}
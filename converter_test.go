@@ -0,0 +1,184 @@
+package set_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+// Money is a type used to test ConverterRegistry.
+type Money struct {
+	Cents int64
+}
+
+// parseMoney converts strings such as "$12.34" into Money.
+func parseMoney(v interface{}) (interface{}, error) {
+	s := strings.TrimPrefix(v.(string), "$")
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return Money{Cents: int64(f*100 + 0.5)}, nil
+}
+
+func TestConverterRegistry(t *testing.T) {
+	errConverter := errors.New("converter error")
+	//
+	t.Run("lookup", func(t *testing.T) {
+		chk := assert.New(t)
+		var nilRegistry *set.ConverterRegistry
+		_, ok := nilRegistry.Lookup(reflect.TypeOf(""), reflect.TypeOf(Money{}))
+		chk.False(ok)
+		//
+		r := set.NewConverterRegistry()
+		_, ok = r.Lookup(reflect.TypeOf(""), reflect.TypeOf(Money{}))
+		chk.False(ok)
+		r.Register("", Money{}, parseMoney)
+		fn, ok := r.Lookup(reflect.TypeOf(""), reflect.TypeOf(Money{}))
+		chk.True(ok)
+		chk.NotNil(fn)
+		// Replace.
+		r.Register("", reflect.TypeOf(Money{}), func(v interface{}) (interface{}, error) { return nil, errConverter })
+		fn, ok = r.Lookup(reflect.TypeOf(""), reflect.TypeOf(Money{}))
+		chk.True(ok)
+		_, err := fn("")
+		chk.ErrorIs(err, errConverter)
+		// Remove.
+		r.Register("", Money{}, nil)
+		r.Register("", Money{}, nil)
+		_, ok = r.Lookup(reflect.TypeOf(""), reflect.TypeOf(Money{}))
+		chk.False(ok)
+	})
+	t.Run("global", func(t *testing.T) {
+		chk := assert.New(t)
+		set.Converters.Register("", Money{}, parseMoney)
+		defer set.Converters.Register("", Money{}, nil)
+		//
+		var m Money
+		err := set.V(&m).To("$12.34")
+		chk.NoError(err)
+		chk.Equal(int64(1234), m.Cents)
+		//
+		var pm *Money
+		err = set.V(&pm).To("$1.00")
+		chk.NoError(err)
+		chk.Equal(int64(100), pm.Cents)
+		//
+		var slice []Money
+		err = set.V(&slice).To([]string{"$1", "$2"})
+		chk.NoError(err)
+		chk.Equal([]Money{{100}, {200}}, slice)
+		//
+		err = set.V(&m).To("abc")
+		chk.Error(err)
+		chk.Equal(int64(0), m.Cents)
+	})
+	t.Run("results", func(t *testing.T) {
+		type Cents int64
+		chk := assert.New(t)
+		set.Converters.Register(0, Cents(0), func(v interface{}) (interface{}, error) { return int64(v.(int) * 100), nil })
+		set.Converters.Register(false, Cents(0), func(v interface{}) (interface{}, error) { return nil, nil })
+		set.Converters.Register("", Cents(0), func(v interface{}) (interface{}, error) { return "nope", nil })
+		defer set.Converters.Register(0, Cents(0), nil)
+		defer set.Converters.Register(false, Cents(0), nil)
+		defer set.Converters.Register("", Cents(0), nil)
+		//
+		var c Cents
+		err := set.V(&c).To(5)
+		chk.NoError(err)
+		chk.Equal(Cents(500), c)
+		err = set.V(&c).To(true)
+		chk.NoError(err)
+		chk.Equal(Cents(0), c)
+		c = 10
+		err = set.V(&c).To("1")
+		chk.ErrorIs(err, set.ErrUnsupported)
+		chk.Equal(Cents(0), c)
+	})
+	t.Run("mapper", func(t *testing.T) {
+		type Order struct {
+			Id    int
+			Total Money
+		}
+		chk := assert.New(t)
+		registry := set.NewConverterRegistry()
+		registry.Register("", Money{}, parseMoney)
+		mapper := &set.Mapper{
+			TreatAsScalar: set.NewTypeList(Money{}),
+			Converters:    registry,
+			Join:          "_",
+		}
+		//
+		var o Order
+		b, err := mapper.Bind(&o)
+		chk.NoError(err)
+		chk.NoError(b.Set("Total", "$5.25"))
+		chk.Equal(int64(525), o.Total.Cents)
+		b = b.Copy()
+		chk.NoError(b.Set("Total", "$6.25"))
+		chk.Equal(int64(625), o.Total.Cents)
+		//
+		p, err := mapper.Prepare(&o)
+		chk.NoError(err)
+		chk.NoError(p.Plan("Id", "Total"))
+		chk.NoError(p.Set("42"))
+		chk.NoError(p.Set("$7.50"))
+		chk.Equal(42, o.Id)
+		chk.Equal(int64(750), o.Total.Cents)
+		p = p.Copy()
+		p.Rebind(&o)
+		chk.NoError(p.Set("43"))
+		chk.NoError(p.Set("$8.50"))
+		chk.Equal(int64(850), o.Total.Cents)
+		//
+		// A Mapper without the registry can not perform the conversion.
+		other := &set.Mapper{
+			TreatAsScalar: set.NewTypeList(Money{}),
+			Join:          "_",
+		}
+		b, err = other.Bind(&o)
+		chk.NoError(err)
+		chk.NoError(b.Set("Total", "$1.00"))
+		chk.Equal(int64(0), o.Total.Cents)
+	})
+	t.Run("same type", func(t *testing.T) {
+		// Converters between a type and itself are used instead of assigning the value directly.
+		type T struct {
+			Name  string
+			Count int
+		}
+		chk := assert.New(t)
+		registry := set.NewConverterRegistry()
+		registry.Register("", "", func(v interface{}) (interface{}, error) { return strings.ToUpper(v.(string)), nil })
+		for _, unsafeOffsets := range []bool{false, true} {
+			mapper := &set.Mapper{Converters: registry, UnsafeOffsets: unsafeOffsets}
+			var dst T
+			b, err := mapper.Bind(&dst)
+			chk.NoError(err)
+			chk.NoError(b.Set("Name", "bob"))
+			chk.NoError(b.Set("Count", 1))
+			chk.Equal(T{Name: "BOB", Count: 1}, dst)
+			//
+			p, err := mapper.Prepare(&dst)
+			chk.NoError(err)
+			chk.NoError(p.Plan("Name", "Count"))
+			chk.NoError(p.Set("alice"))
+			chk.NoError(p.Set(2))
+			chk.Equal(T{Name: "ALICE", Count: 2}, dst)
+		}
+		//
+		set.Converters.Register("", "", func(v interface{}) (interface{}, error) { return "global " + v.(string), nil })
+		defer set.Converters.Register("", "", nil)
+		var dst T
+		b, err := set.DefaultMapper.Bind(&dst)
+		chk.NoError(err)
+		chk.NoError(b.Set("Name", "carol"))
+		chk.Equal("global carol", dst.Name)
+	})
+}
@@ -0,0 +1,207 @@
+package csv
+
+import (
+	stdcsv "encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+// DefaultMapper is the Mapper used by a Decoder or Encoder when its Mapper is nil.
+//
+// Struct tags named csv override field names and nested names are joined with "_".
+var DefaultMapper = &set.Mapper{
+	Tags: []string{"csv"},
+	Join: "_",
+}
+
+// UnknownColumnPolicy controls how a Decoder handles header columns that do not match a field.
+type UnknownColumnPolicy int
+
+const (
+	// UnknownColumnError causes Decode to return an error wrapping ErrUnknownColumn.
+	UnknownColumnError UnknownColumnPolicy = iota
+
+	// UnknownColumnIgnore causes Decode to skip the values in unknown columns.
+	UnknownColumnIgnore
+)
+
+// Decoder reads structs from CSV data.
+//
+// Mapper and UnknownColumns must be set before the first call to Decode or DecodeAll.
+type Decoder struct {
+	// Mapper creates the keys matched against the header; if nil then DefaultMapper is used.
+	Mapper *set.Mapper
+
+	// UnknownColumns is the policy for header columns that do not match a field.
+	UnknownColumns UnknownColumnPolicy
+
+	r *stdcsv.Reader
+
+	// header is a copy of the first record and headerLine is its line number; records is the
+	// number of records read including the header.
+	header     []string
+	headerLine int
+	records    int
+
+	// prepared has a plan for the columns in the header that match a field of typ; columns
+	// contains the index in header of each planned column and hex is true for the planned
+	// columns that are byte arrays written as hexadecimal by an Encoder.
+	typ      reflect.Type
+	prepared set.PreparedMapping
+	columns  []int
+	hex      []bool
+}
+
+// NewDecoder returns a Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	rv := &Decoder{
+		r: stdcsv.NewReader(r),
+	}
+	rv.r.ReuseRecord = true
+	return rv
+}
+
+// Reader returns the underlying csv.Reader so options such as Comma can be changed before the
+// first call to Header, Decode, or DecodeAll.
+func (d *Decoder) Reader() *stdcsv.Reader {
+	return d.r
+}
+
+// Header reads the header if it has not been read and returns the column names.
+func (d *Decoder) Header() ([]string, error) {
+	if d.header == nil {
+		record, err := d.r.Read()
+		if err != nil {
+			return nil, err
+		}
+		d.records++
+		d.header = append(make([]string, 0, len(record)), record...)
+		d.headerLine = recordLine(d.r, d.records)
+	}
+	return append([]string(nil), d.header...), nil
+}
+
+// Decode reads the next record into dst, which must be a pointer to a struct.  Columns that are
+// not present in the header leave their fields unchanged and empty values assign the zero
+// value.  Byte arrays such as [16]byte are read as hexadecimal; see Encoder.Encode.
+//
+// At the end of the data Decode returns io.EOF.  If a value can not be assigned to its field
+// then a *RowError is returned and the remaining columns are not assigned.  Errors reading the
+// CSV data are returned as they are; see csv.ParseError.
+//
+// As a convenience dst can be a reflect.Value.
+func (d *Decoder) Decode(dst interface{}) error {
+	var v reflect.Value
+	switch sw := dst.(type) {
+	case reflect.Value:
+		v = sw
+	default:
+		v = reflect.ValueOf(dst)
+	}
+	if v.Kind() != reflect.Ptr || v.Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w; expected pointer to struct but got %v", ErrUnsupportedType, v.Type())
+	} else if v.IsNil() {
+		return fmt.Errorf("%w; nil %v", ErrUnsupportedType, v.Type())
+	}
+	return d.decode(v)
+}
+
+// DecodeAll reads the remaining records and appends them to dst, which must be a pointer to a
+// slice of structs or pointers to structs.  It returns nil at the end of the data.
+//
+// The records decoded before an error are appended to dst.
+func (d *Decoder) DecodeAll(dst interface{}) error {
+	slice, err := set.Slice(dst)
+	if err != nil {
+		return err
+	} else if slice.ElemEndType.Kind() != reflect.Struct {
+		return fmt.Errorf("%w; expected slice of struct but got %v", ErrUnsupportedType, slice.V.Type())
+	}
+	for {
+		elem, target := slice.ElemEnd()
+		if err = d.decode(target); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		slice.Append(elem)
+	}
+}
+
+// decode reads the next record into v, which is a non-nil pointer to a struct.
+func (d *Decoder) decode(v reflect.Value) error {
+	if _, err := d.Header(); err != nil {
+		return err
+	} else if err = d.prepare(v.Type()); err != nil {
+		return err
+	}
+	record, err := d.r.Read()
+	if err != nil {
+		return err
+	}
+	d.records++
+	//
+	d.prepared.Rebind(v)
+	for k, column := range d.columns {
+		var value interface{} = record[column]
+		if record[column] == "" {
+			value = nil
+		} else if d.hex[k] {
+			if value, err = hex.DecodeString(record[column]); err != nil {
+				return &RowError{Line: recordLine(d.r, d.records), Column: d.header[column], Err: err}
+			}
+		}
+		if err = d.prepared.Set(value); err != nil {
+			return &RowError{Line: recordLine(d.r, d.records), Column: d.header[column], Err: err}
+		}
+	}
+	return nil
+}
+
+// prepare creates the PreparedMapping and plan for the header and T if T is not the type of
+// the previous call.
+func (d *Decoder) prepare(T reflect.Type) error {
+	if T == d.typ {
+		return nil
+	}
+	mapper := d.Mapper
+	if mapper == nil {
+		mapper = DefaultMapper
+	}
+	prepared, err := mapper.Prepare(reflect.New(T.Elem()))
+	if err != nil {
+		return err
+	}
+	columns, unknown, err := prepared.PlanKnown(d.header...)
+	if err != nil {
+		return &RowError{Line: d.headerLine, Column: d.header[len(columns)+len(unknown)], Err: err}
+	} else if len(unknown) > 0 && d.UnknownColumns == UnknownColumnError {
+		names := make([]string, len(unknown))
+		for k, column := range unknown {
+			names[k] = d.header[column]
+		}
+		return &RowError{
+			Line: d.headerLine,
+			Err:  fmt.Errorf("%w [%v] for %v", ErrUnknownColumn, strings.Join(names, ", "), T.Elem()),
+		}
+	}
+	//
+	// The bound struct is only used to find the types of the planned columns.
+	isHex := make([]bool, len(columns))
+	for k, column := range columns {
+		field, err := prepared.Field()
+		if err != nil {
+			return &RowError{Line: d.headerLine, Column: d.header[column], Err: err}
+		}
+		isHex[k] = field.IsArray && field.ElemType.Kind() == reflect.Uint8
+	}
+	//
+	d.typ, d.prepared, d.columns, d.hex = T, prepared, columns, isHex
+	return nil
+}
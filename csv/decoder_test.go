@@ -0,0 +1,120 @@
+package csv_test
+
+import (
+	stdcsv "encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/csv"
+)
+
+type Address struct {
+	Street string `csv:"street"`
+	City   string `csv:"city"`
+}
+
+type Person struct {
+	ID      int       `csv:"id"`
+	Name    string    `csv:"name"`
+	Born    time.Time `csv:"born"`
+	Address Address   `csv:"address"`
+	Manager *Person   `csv:"-"`
+}
+
+func TestDecoder(t *testing.T) {
+	t.Run("decode all", func(t *testing.T) {
+		chk := assert.New(t)
+		data := "name,id,address_city,born\n" +
+			"Alice,1,Boston,2000-01-02T00:00:00Z\n" +
+			"\"Bob\nSmith\",2,Denver,\n"
+		var people []Person
+		err := csv.NewDecoder(strings.NewReader(data)).DecodeAll(&people)
+		chk.NoError(err)
+		chk.Equal([]Person{
+			{ID: 1, Name: "Alice", Born: time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), Address: Address{City: "Boston"}},
+			{ID: 2, Name: "Bob\nSmith", Address: Address{City: "Denver"}},
+		}, people)
+		//
+		var pointers []*Person
+		err = csv.NewDecoder(strings.NewReader(data)).DecodeAll(&pointers)
+		chk.NoError(err)
+		chk.Len(pointers, 2)
+		chk.Equal("Bob\nSmith", pointers[1].Name)
+	})
+	t.Run("decode", func(t *testing.T) {
+		chk := assert.New(t)
+		d := csv.NewDecoder(strings.NewReader("id,name\n1,Alice\n"))
+		header, err := d.Header()
+		chk.NoError(err)
+		chk.Equal([]string{"id", "name"}, header)
+		p := Person{Address: Address{City: "Boston"}}
+		chk.NoError(d.Decode(&p))
+		chk.Equal(Person{ID: 1, Name: "Alice", Address: Address{City: "Boston"}}, p)
+		chk.ErrorIs(d.Decode(&p), io.EOF)
+		//
+		chk.ErrorIs(d.Decode(p), csv.ErrUnsupportedType)
+		chk.ErrorIs(d.Decode((*Person)(nil)), csv.ErrUnsupportedType)
+		chk.ErrorIs(csv.NewDecoder(strings.NewReader("")).Decode(&p), io.EOF)
+	})
+	t.Run("row errors", func(t *testing.T) {
+		chk := assert.New(t)
+		data := "id,name\n1,Alice\n2,\"Bob\nJr\"\nabc,Carol\n"
+		var people []Person
+		err := csv.NewDecoder(strings.NewReader(data)).DecodeAll(&people)
+		var rowErr *csv.RowError
+		chk.True(errors.As(err, &rowErr))
+		chk.Equal(5, rowErr.Line)
+		chk.Equal("id", rowErr.Column)
+		chk.Equal([]Person{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob\nJr"}}, people)
+		//
+		err = csv.NewDecoder(strings.NewReader("id,name\n1,Alice,extra\n")).DecodeAll(&people)
+		var parseErr *stdcsv.ParseError
+		chk.True(errors.As(err, &parseErr))
+	})
+	t.Run("unknown columns", func(t *testing.T) {
+		chk := assert.New(t)
+		data := "id,age,name,email\n1,30,Alice,alice@example.com\n"
+		var people []Person
+		err := csv.NewDecoder(strings.NewReader(data)).DecodeAll(&people)
+		chk.ErrorIs(err, csv.ErrUnknownColumn)
+		chk.Equal("csv: line 1: csv: unknown column [age, email] for csv_test.Person", err.Error())
+		chk.Empty(people)
+		//
+		d := csv.NewDecoder(strings.NewReader(data))
+		d.UnknownColumns = csv.UnknownColumnIgnore
+		chk.NoError(d.DecodeAll(&people))
+		chk.Equal([]Person{{ID: 1, Name: "Alice"}}, people)
+	})
+	t.Run("mapper", func(t *testing.T) {
+		chk := assert.New(t)
+		type T struct {
+			FirstName string
+			UserID    int
+		}
+		d := csv.NewDecoder(strings.NewReader("First Name;User ID\nAlice;7\n"))
+		d.Reader().Comma = ';'
+		d.Mapper = &set.Mapper{Normalize: set.NormalizeKey}
+		var rows []T
+		chk.NoError(d.DecodeAll(&rows))
+		chk.Equal([]T{{FirstName: "Alice", UserID: 7}}, rows)
+		//
+		type Ambiguous struct {
+			UserID  int
+			User_ID int
+		}
+		d = csv.NewDecoder(strings.NewReader("userid\n7\n"))
+		d.Mapper = &set.Mapper{Normalize: set.NormalizeKey}
+		var ambiguous []Ambiguous
+		err := d.DecodeAll(&ambiguous)
+		chk.ErrorIs(err, set.ErrAmbiguousField)
+		var rowErr *csv.RowError
+		chk.True(errors.As(err, &rowErr))
+		chk.Equal("userid", rowErr.Column)
+	})
+}
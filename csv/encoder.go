@@ -0,0 +1,203 @@
+package csv
+
+import (
+	"encoding"
+	stdcsv "encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/coerce"
+)
+
+// Encoder writes structs as CSV data.
+//
+// Mapper must be set before the first call to Encode or EncodeAll.
+type Encoder struct {
+	// Mapper creates the column names; if nil then DefaultMapper is used.
+	Mapper *set.Mapper
+
+	w *stdcsv.Writer
+
+	// typ is the struct type that created keys and prepared; prepared is planned with keys and
+	// is rebound to each struct to read its values.  records is the number of records written
+	// including the header.
+	typ      reflect.Type
+	keys     []string
+	prepared set.PreparedMapping
+	records  int
+	values   []interface{}
+	record   []string
+}
+
+// NewEncoder returns an Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		w: stdcsv.NewWriter(w),
+	}
+}
+
+// Writer returns the underlying csv.Writer so options such as Comma can be changed before the
+// first call to Encode or EncodeAll.
+func (e *Encoder) Writer() *stdcsv.Writer {
+	return e.w
+}
+
+// Encode writes v as a record; v must be a struct or a non-nil pointer to a struct.
+//
+// The first call to Encode writes the header, which contains the keys of the Mapping for v's
+// type in the order of Mapping.Keys; keys for the elements of slices and keys for arrays
+// whose elements are mapped are not written.  Every later call must have the same type.
+//
+// Values are formatted with coerce.String and nil pointers are written as empty strings; byte
+// arrays such as [16]byte are written as hexadecimal so the Decoder reads them back unchanged.
+// Records are buffered; call Flush when finished.
+//
+// As a convenience v can be a reflect.Value.
+func (e *Encoder) Encode(v interface{}) error {
+	var rv reflect.Value
+	switch sw := v.(type) {
+	case reflect.Value:
+		rv = sw
+	default:
+		rv = reflect.ValueOf(v)
+	}
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w; expected struct but got %T", ErrUnsupportedType, v)
+	} else if err := e.begin(rv.Type()); err != nil {
+		return err
+	}
+	//
+	e.prepared.Rebind(rv)
+	values, err := e.prepared.ReadFields(e.values)
+	if err != nil {
+		return err
+	}
+	line := e.records + 1
+	for k, key := range e.keys {
+		if e.record[k], err = format(values[k]); err != nil {
+			return &RowError{Line: line, Column: key, Err: err}
+		}
+	}
+	if err = e.w.Write(e.record); err != nil {
+		return err
+	}
+	e.records++
+	return nil
+}
+
+// EncodeAll writes the elements of v, which must be a slice of structs or pointers to structs
+// or a pointer to such a slice, and then calls Flush.  The header is written even if v is
+// empty.
+func (e *Encoder) EncodeAll(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("%w; expected slice but got %T", ErrUnsupportedType, v)
+	}
+	T := rv.Type().Elem()
+	for T.Kind() == reflect.Ptr {
+		T = T.Elem()
+	}
+	if T.Kind() != reflect.Struct {
+		return fmt.Errorf("%w; expected slice of struct but got %T", ErrUnsupportedType, v)
+	} else if err := e.begin(T); err != nil {
+		return err
+	}
+	for k, size := 0, rv.Len(); k < size; k++ {
+		if err := e.Encode(rv.Index(k)); err != nil {
+			return err
+		}
+	}
+	return e.Flush()
+}
+
+// Flush writes any buffered data and returns any error that occurred during a previous write
+// or flush.
+func (e *Encoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// begin writes the header for T on the first call and returns an error wrapping
+// ErrMismatchedType if T differs from the type of the first call.
+func (e *Encoder) begin(T reflect.Type) error {
+	if e.typ != nil {
+		if T != e.typ {
+			return fmt.Errorf("%w; expected %v but got %v", ErrMismatchedType, e.typ, T)
+		}
+		return nil
+	}
+	//
+	mapper := e.mapper()
+	mapping := mapper.Map(reflect.New(T).Interface())
+	keys := make([]string, 0, len(mapping.Keys))
+	for _, key := range mapping.Keys {
+		if strings.Contains(key, set.IndexPlaceholder) || isArrayKey(mapping, key) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	prepared, err := mapper.PrepareReadOnly(reflect.Zero(T))
+	if err != nil {
+		return err
+	} else if err = prepared.Plan(keys...); err != nil {
+		return err
+	}
+	if err = e.w.Write(keys); err != nil {
+		return err
+	}
+	e.typ, e.keys, e.prepared, e.records = T, keys, prepared, 1
+	e.values, e.record = make([]interface{}, len(keys)), make([]string, len(keys))
+	return nil
+}
+
+// format returns value formatted for a record; see Encode.
+func format(value interface{}) (string, error) {
+	if _, ok := value.(encoding.TextMarshaler); ok {
+		return coerce.String(value)
+	} else if rv := reflect.ValueOf(value); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		for n := range b {
+			b[n] = byte(rv.Index(n).Uint())
+		}
+		return hex.EncodeToString(b), nil
+	}
+	return coerce.String(value)
+}
+
+// isArrayKey returns true if key maps an array other than a byte array as a whole; its elements
+// have their own keys and columns.
+func isArrayKey(mapping set.Mapping, key string) bool {
+	T := mapping.StructFields[key].Type
+	for T.Kind() == reflect.Ptr {
+		T = T.Elem()
+	}
+	if T.Kind() != reflect.Array || T.Elem().Kind() == reflect.Uint8 {
+		return false
+	}
+	// The keys for the elements of an array of scalars share its StructField; their paths end
+	// at the element instead of the array.
+	for p := mapping.ReflectPaths[key]; p.Elem != nil; p = *p.Elem.Path {
+		if p.Elem.Path == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// mapper returns the Mapper or DefaultMapper if it is nil.
+func (e *Encoder) mapper() *set.Mapper {
+	if e.Mapper == nil {
+		return DefaultMapper
+	}
+	return e.Mapper
+}
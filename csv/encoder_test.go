@@ -0,0 +1,153 @@
+package csv_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/coerce"
+	"github.com/nofeaturesonlybugs/set/csv"
+)
+
+// secret can not be marshaled unless it is empty.
+type secret string
+
+func (s secret) MarshalText() ([]byte, error) {
+	if s != "" {
+		return nil, errors.New("secret")
+	}
+	return nil, nil
+}
+
+func TestEncoder(t *testing.T) {
+	t.Run("encode all", func(t *testing.T) {
+		chk := assert.New(t)
+		people := []Person{
+			{ID: 1, Name: "Alice", Born: time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), Address: Address{City: "Boston"}},
+			{ID: 2, Name: "Bob, Jr.", Address: Address{Street: "1 Main St", City: "Denver"}},
+		}
+		var b strings.Builder
+		chk.NoError(csv.NewEncoder(&b).EncodeAll(people))
+		chk.Equal("id,name,born,address_street,address_city\n"+
+			"1,Alice,2000-01-02T00:00:00Z,,Boston\n"+
+			"2,\"Bob, Jr.\",0001-01-01T00:00:00Z,1 Main St,Denver\n", b.String())
+		//
+		// Round trip.
+		var decoded []Person
+		chk.NoError(csv.NewDecoder(strings.NewReader(b.String())).DecodeAll(&decoded))
+		chk.Equal(people, decoded)
+		//
+		b.Reset()
+		chk.NoError(csv.NewEncoder(&b).EncodeAll(&[]*Person{}))
+		chk.Equal("id,name,born,address_street,address_city\n", b.String())
+	})
+	t.Run("encode", func(t *testing.T) {
+		chk := assert.New(t)
+		type Point struct {
+			X, Y float64
+		}
+		type T struct {
+			Name   string
+			Vec    [2]int
+			Tags   []string
+			Points []Point
+			Origin *Point
+			Rate   *float64
+		}
+		var b strings.Builder
+		e := csv.NewEncoder(&b)
+		e.Writer().Comma = '\t'
+		e.Mapper = &set.Mapper{Join: "_", IndexSlices: true}
+		rate := 0.5
+		chk.NoError(e.Encode(T{Name: "a", Vec: [2]int{1, 2}, Tags: []string{"x"}, Points: []Point{{1, 2}}}))
+		chk.NoError(e.Encode(&T{Name: "b", Origin: &Point{X: 3}, Rate: &rate}))
+		chk.NoError(e.Flush())
+		chk.Equal("Name\tVec_0\tVec_1\tOrigin_X\tOrigin_Y\tRate\n"+
+			"a\t1\t2\t\t\t\n"+
+			"b\t0\t0\t3\t0\t0.5\n", b.String())
+		//
+		chk.ErrorIs(e.Encode(Point{}), csv.ErrMismatchedType)
+		chk.ErrorIs(e.Encode(42), csv.ErrUnsupportedType)
+		chk.ErrorIs(e.EncodeAll([]int{1}), csv.ErrUnsupportedType)
+		chk.ErrorIs(e.EncodeAll(T{}), csv.ErrUnsupportedType)
+	})
+	t.Run("array keys", func(t *testing.T) {
+		chk := assert.New(t)
+		type Point struct {
+			Vec [2]int
+		}
+		type T struct {
+			Foo    int
+			Foo0   int `csv:"Foo_0"`
+			Points [2]Point
+			Ptr    *[1]int
+		}
+		var b strings.Builder
+		e := csv.NewEncoder(&b)
+		chk.NoError(e.Encode(T{Foo: 1, Foo0: 2, Points: [2]Point{{Vec: [2]int{3, 4}}}}))
+		chk.NoError(e.Flush())
+		chk.Equal("Foo,Foo_0,Points_0_Vec_0,Points_0_Vec_1,Points_1_Vec_0,Points_1_Vec_1,Ptr_0\n"+
+			"1,2,3,4,0,0,\n", b.String())
+	})
+	t.Run("byte arrays", func(t *testing.T) {
+		chk := assert.New(t)
+		type T struct {
+			Name string
+			Hash [4]byte
+		}
+		mapper := &set.Mapper{Join: "_"}
+		rows := []T{
+			{Name: "a", Hash: [4]byte{'w', 'x', 'y', 'z'}},
+			{Name: "b", Hash: [4]byte{'\r', '\n', 0x00, 0xff}},
+			{Name: "c"},
+		}
+		var b strings.Builder
+		e := csv.NewEncoder(&b)
+		e.Mapper = mapper
+		chk.NoError(e.EncodeAll(rows))
+		chk.Equal("Name,Hash\na,7778797a\nb,0d0a00ff\nc,00000000\n", b.String())
+		//
+		var decoded []T
+		d := csv.NewDecoder(strings.NewReader(b.String()))
+		d.Mapper = mapper
+		chk.NoError(d.DecodeAll(&decoded))
+		chk.Equal(rows, decoded)
+		//
+		d = csv.NewDecoder(strings.NewReader("Name,Hash\na,wxyz\n"))
+		d.Mapper = mapper
+		var rowErr *csv.RowError
+		chk.True(errors.As(d.DecodeAll(&decoded), &rowErr))
+		chk.Equal(2, rowErr.Line)
+		chk.Equal("Hash", rowErr.Column)
+	})
+	t.Run("row error", func(t *testing.T) {
+		chk := assert.New(t)
+		type T struct {
+			Name   string
+			Secret secret
+		}
+		var b strings.Builder
+		err := csv.NewEncoder(&b).EncodeAll([]T{{Name: "a"}, {Name: "b", Secret: "x"}})
+		var rowErr *csv.RowError
+		chk.True(errors.As(err, &rowErr))
+		chk.Equal(3, rowErr.Line)
+		chk.Equal("Secret", rowErr.Column)
+		chk.ErrorIs(err, coerce.ErrInvalid)
+	})
+}
+
+func BenchmarkEncoder(b *testing.B) {
+	person := Person{ID: 1, Name: "Alice", Born: time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), Address: Address{City: "Boston"}}
+	e := csv.NewEncoder(io.Discard)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if err := e.Encode(&person); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The following errors can be checked with errors.Is.
+var (
+	// ErrMismatchedType occurs when an Encoder is given a different type than the type that
+	// created its header.
+	ErrMismatchedType = errors.New("csv: mismatched type")
+
+	// ErrUnknownColumn occurs when a column in the header does not match any field and the
+	// Decoder's UnknownColumns is UnknownColumnError.
+	ErrUnknownColumn = errors.New("csv: unknown column")
+
+	// ErrUnsupportedType occurs when the destination or source is not a struct.
+	ErrUnsupportedType = errors.New("csv: unsupported type")
+)
+
+// RowError describes an error decoding or encoding a single record.
+type RowError struct {
+	// Line is the line number of the record; the header is line 1.
+	Line int
+
+	// Column is the column name or empty if the error does not belong to a single column.
+	Column string
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the error as a string.
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("csv: line %v: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("csv: line %v, column %v: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
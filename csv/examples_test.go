@@ -0,0 +1,54 @@
+package csv_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/set/csv"
+)
+
+func ExampleDecoder() {
+	type Address struct {
+		ID     int    `csv:"id"`
+		Street string `csv:"address"`
+		City   string `csv:"city"`
+		Zip    string `csv:"postal"`
+	}
+	data := `city,address,postal,id
+Tuscaloosa,2607 Hanson Junction,35487,1
+Bakersfield,2 Sherman Place,93305,2
+Kansas City,4 Porter Place,64199,x
+`
+	var addresses []Address
+	err := csv.NewDecoder(strings.NewReader(data)).DecodeAll(&addresses)
+	for _, a := range addresses {
+		fmt.Printf("ID=%v %v, %v  %v\n", a.ID, a.Street, a.City, a.Zip)
+	}
+	if rowErr, ok := err.(*csv.RowError); ok {
+		fmt.Printf("line %v column %v\n", rowErr.Line, rowErr.Column)
+	}
+
+	// Output: ID=1 2607 Hanson Junction, Tuscaloosa  35487
+	// ID=2 2 Sherman Place, Bakersfield  93305
+	// line 4 column id
+}
+
+func ExampleEncoder() {
+	type Address struct {
+		ID     int    `csv:"id"`
+		Street string `csv:"address"`
+		City   string `csv:"city"`
+	}
+	addresses := []Address{
+		{ID: 1, Street: "2607 Hanson Junction", City: "Tuscaloosa"},
+		{ID: 2, Street: "2 Sherman Place", City: "Bakersfield"},
+	}
+	if err := csv.NewEncoder(os.Stdout).EncodeAll(addresses); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output: id,address,city
+	// 1,2607 Hanson Junction,Tuscaloosa
+	// 2,2 Sherman Place,Bakersfield
+}
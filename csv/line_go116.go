@@ -0,0 +1,17 @@
+//go:build !go1.17
+// +build !go1.17
+
+package csv
+
+import (
+	stdcsv "encoding/csv"
+)
+
+// recordLine returns the line on which the record most recently read from r begins; records
+// is the number of records read.
+//
+// Before Go 1.17 csv.Reader does not report field positions so the record number is used; it
+// is only the line number if no previous record contains a quoted newline.
+func recordLine(r *stdcsv.Reader, records int) int {
+	return records
+}
@@ -0,0 +1,33 @@
+// Package csv decodes CSV records into structs and encodes structs as CSV records using a
+// set.Mapper.
+//
+// Columns
+//
+// The first record is the header and each column name is a key generated by the Mapper.  The
+// DefaultMapper uses struct tags named csv and joins nested names with "_":
+//	type Address struct {
+//		Street string `csv:"street"`
+//		City   string `csv:"city"`
+//	}
+//	type Person struct {
+//		Name    string `csv:"name"`
+//		Address Address `csv:"address"`
+//	}
+//	// Columns: name, address_street, address_city
+//
+// Decoding
+//
+// A Decoder reads the header once and creates an access plan with set.PreparedMapping.Plan;
+// columns may appear in any order.  Columns that do not match a field are an error unless
+// the Decoder's UnknownColumns is UnknownColumnIgnore.  Values are coerced with the same rules
+// as set.Value.To.
+//
+// Errors assigning a record are returned as a *RowError containing the line number and the
+// column.
+//
+// Encoding
+//
+// An Encoder writes a header containing the keys in set.Mapping.Keys order followed by one
+// record per struct; values are formatted with coerce.String and byte arrays such as [16]byte
+// are written as hexadecimal.
+package csv
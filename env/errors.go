@@ -0,0 +1,78 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The following errors are wrapped by Error and can be checked with errors.Is.
+var (
+	// ErrInvalidVariable occurs when the value of a variable can not be assigned to its field.
+	ErrInvalidVariable = errors.New("env: invalid variable")
+
+	// ErrMissingVariable occurs when a variable for a required field is not set.
+	ErrMissingVariable = errors.New("env: missing variable")
+
+	// ErrUnknownVariable occurs when a variable beginning with the Loader's Prefix does not match
+	// any field.
+	ErrUnknownVariable = errors.New("env: unknown variable")
+)
+
+// Error is returned by Loader.Load and describes every variable that could not be loaded.
+//
+// Fields whose variables could be assigned are filled even when an Error is returned.
+type Error struct {
+	// Unknown contains the names of variables beginning with the Loader's Prefix that do not
+	// match any field.
+	Unknown []string
+
+	// Missing contains the names of variables for required fields that are not set.
+	Missing []string
+
+	// Invalid contains the error returned by set.BoundMapping.Set for each variable whose value
+	// could not be assigned.
+	Invalid map[string]error
+}
+
+// Error returns the error as a string.
+func (e *Error) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, fmt.Sprintf("%v [%v]", ErrMissingVariable, strings.Join(e.Missing, ", ")))
+	}
+	if len(e.Invalid) > 0 {
+		names := make([]string, 0, len(e.Invalid))
+		for name := range e.Invalid {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("%v [%v]: %v", ErrInvalidVariable, name, e.Invalid[name]))
+		}
+	}
+	if len(e.Unknown) > 0 {
+		parts = append(parts, fmt.Sprintf("%v [%v]", ErrUnknownVariable, strings.Join(e.Unknown, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Is returns true if target is ErrUnknownVariable, ErrMissingVariable, or ErrInvalidVariable and
+// the corresponding field of Error is not empty.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrUnknownVariable:
+		return len(e.Unknown) > 0
+	case ErrMissingVariable:
+		return len(e.Missing) > 0
+	case ErrInvalidVariable:
+		return len(e.Invalid) > 0
+	}
+	return false
+}
+
+// empty returns true if there are no errors.
+func (e *Error) empty() bool {
+	return len(e.Unknown) == 0 && len(e.Missing) == 0 && len(e.Invalid) == 0
+}
@@ -0,0 +1,104 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+// DefaultMapper is the Mapper used by a Loader when its Mapper is nil.
+//
+// Names are created with set.ScreamingSnakeCase and joined with "_", struct tags named env
+// override names, and slices of structs are indexed up to MaxSliceIndex; variables with larger
+// indexes are unknown.
+var DefaultMapper = &set.Mapper{
+	Tags:          []string{"env"},
+	Transform:     set.ScreamingSnakeCase,
+	Join:          "_",
+	IndexSlices:   true,
+	MaxSliceIndex: MaxSliceIndex,
+}
+
+// MaxSliceIndex is the largest slice index in variable names accepted by DefaultMapper.
+const MaxSliceIndex = 99
+
+// Loader fills structs from environment variables.
+type Loader struct {
+	// Prefix is joined to every key with the Mapper's Join to create variable names; for
+	// example the prefix APP and key PORT create the variable APP_PORT.
+	//
+	// When Prefix is not empty variables beginning with the prefix that do not match a field
+	// are reported in Error.Unknown.  When Prefix is empty unknown variables are ignored.
+	Prefix string
+
+	// Mapper creates the keys; if nil then DefaultMapper is used.
+	Mapper *set.Mapper
+}
+
+// Load fills dst from the variables in os.Environ with the given prefix; see Loader.
+func Load(dst interface{}, prefix string) error {
+	return Loader{Prefix: prefix}.Load(dst, os.Environ())
+}
+
+// Load fills dst from environ, which contains variables in the form NAME=VALUE such as
+// returned by os.Environ.  dst must be a pointer to a struct.
+//
+// Every variable that matches a field is assigned even when other variables cause errors.  If
+// any variables are unknown, missing, or invalid then a *Error is returned.
+func (l Loader) Load(dst interface{}, environ []string) error {
+	mapper := l.Mapper
+	if mapper == nil {
+		mapper = DefaultMapper
+	}
+	b, err := mapper.Bind(dst)
+	if err != nil {
+		return err
+	}
+	prefix := l.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, mapper.Join) {
+		prefix = prefix + mapper.Join
+	}
+	//
+	rv := &Error{}
+	assigned := map[string]struct{}{}
+	for _, variable := range environ {
+		n := strings.Index(variable, "=")
+		if n <= 0 {
+			// Windows has variables such as =C:=C:\ that begin with =.
+			continue
+		}
+		name, value := variable[:n], variable[n+1:]
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		key := name[len(prefix):]
+		if err = b.Set(key, value); errors.Is(err, set.ErrUnknownField) {
+			if l.Prefix != "" {
+				rv.Unknown = append(rv.Unknown, name)
+			}
+			continue
+		} else if err != nil {
+			if rv.Invalid == nil {
+				rv.Invalid = map[string]error{}
+			}
+			rv.Invalid[name] = err
+		}
+		assigned[key] = struct{}{}
+	}
+	//
+	mapping := mapper.Map(dst)
+	for _, key := range mapping.Keys {
+		if _, ok := assigned[key]; !ok && mapping.Options[key].Has("required") && !strings.Contains(key, set.IndexPlaceholder) {
+			rv.Missing = append(rv.Missing, prefix+key)
+		}
+	}
+	//
+	if rv.empty() {
+		return nil
+	}
+	sort.Strings(rv.Unknown)
+	return rv
+}
@@ -0,0 +1,53 @@
+package env_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nofeaturesonlybugs/set/env"
+)
+
+func ExampleLoader() {
+	type Config struct {
+		HTTPServer struct {
+			Host string
+			Port int
+		}
+		Token string `env:"API_TOKEN,required"`
+	}
+	environ := []string{
+		"APP_HTTP_SERVER_HOST=localhost",
+		"APP_HTTP_SERVER_PORT=8080",
+		"APP_API_TOKEN=secret",
+	}
+	var c Config
+	err := env.Loader{Prefix: "APP"}.Load(&c, environ)
+	fmt.Println(err)
+	fmt.Println(c.HTTPServer.Host, c.HTTPServer.Port, c.Token)
+
+	// Output: <nil>
+	// localhost 8080 secret
+}
+
+func ExampleError() {
+	type Config struct {
+		Port  int
+		Token string `env:"API_TOKEN,required"`
+	}
+	environ := []string{
+		"APP_PORT=8080",
+		"APP_PROT=8081",
+	}
+	var c Config
+	err := env.Loader{Prefix: "APP"}.Load(&c, environ)
+	var envErr *env.Error
+	if errors.As(err, &envErr) {
+		fmt.Println("missing", envErr.Missing)
+		fmt.Println("unknown", envErr.Unknown)
+	}
+	fmt.Println(c.Port)
+
+	// Output: missing [APP_API_TOKEN]
+	// unknown [APP_PROT]
+	// 8080
+}
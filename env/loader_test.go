@@ -0,0 +1,139 @@
+package env_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/env"
+)
+
+func TestLoader(t *testing.T) {
+	type Server struct {
+		Host string
+		Port int
+	}
+	type Config struct {
+		HTTPServer Server
+		Servers    []Server
+		Token      string        `env:"API_TOKEN,required"`
+		Name       string        `env:",required"`
+		Timeout    time.Duration `env:"TIMEOUT"`
+		Debug      bool
+		Ignored    string `env:"-"`
+	}
+	t.Run("load", func(t *testing.T) {
+		chk := assert.New(t)
+		var c Config
+		err := env.Loader{Prefix: "APP"}.Load(&c, []string{
+			"APP_HTTP_SERVER_HOST=localhost",
+			"APP_HTTP_SERVER_PORT=8080",
+			"APP_SERVERS_1_HOST=db",
+			"APP_SERVERS_0_PORT=5432",
+			"APP_API_TOKEN=secret",
+			"APP_NAME=app=1",
+			"APP_TIMEOUT=1m30s",
+			"APP_DEBUG=true",
+			"PATH=/usr/bin",
+			"=C:=C:\\",
+		})
+		chk.NoError(err)
+		chk.Equal(Config{
+			HTTPServer: Server{Host: "localhost", Port: 8080},
+			Servers:    []Server{{Port: 5432}, {Host: "db"}},
+			Token:      "secret",
+			Name:       "app=1",
+			Timeout:    90 * time.Second,
+			Debug:      true,
+		}, c)
+	})
+	t.Run("errors", func(t *testing.T) {
+		chk := assert.New(t)
+		var c Config
+		err := env.Loader{Prefix: "APP_"}.Load(&c, []string{
+			"APP_HTTP_SERVER_PORT=abc",
+			"APP_DEBUG=true",
+			"APP_UNKNOWN=1",
+			"APP_IGNORED=1",
+			"APP_SERVERS_X_HOST=1",
+			"APP_SERVERS_999999999_HOST=1",
+			"OTHER_VALUE=1",
+		})
+		chk.ErrorIs(err, env.ErrUnknownVariable)
+		chk.ErrorIs(err, env.ErrMissingVariable)
+		chk.ErrorIs(err, env.ErrInvalidVariable)
+		var envErr *env.Error
+		chk.True(errors.As(err, &envErr))
+		chk.Equal([]string{"APP_IGNORED", "APP_SERVERS_999999999_HOST", "APP_SERVERS_X_HOST", "APP_UNKNOWN"}, envErr.Unknown)
+		chk.Equal([]string{"APP_API_TOKEN", "APP_NAME"}, envErr.Missing)
+		chk.Len(envErr.Invalid, 1)
+		chk.Error(envErr.Invalid["APP_HTTP_SERVER_PORT"])
+		chk.Equal("env: missing variable [APP_API_TOKEN, APP_NAME]; "+
+			"env: invalid variable [APP_HTTP_SERVER_PORT]: "+envErr.Invalid["APP_HTTP_SERVER_PORT"].Error()+"; "+
+			"env: unknown variable [APP_IGNORED, APP_SERVERS_999999999_HOST, APP_SERVERS_X_HOST, APP_UNKNOWN]", err.Error())
+		// Valid variables are still assigned.
+		chk.True(c.Debug)
+		chk.Nil(c.Servers)
+		//
+		// Without a prefix unknown variables are ignored.
+		err = env.Loader{}.Load(&c, []string{"API_TOKEN=a", "NAME=b", "PATH=/usr/bin"})
+		chk.NoError(err)
+		chk.Equal("a", c.Token)
+		//
+		err = env.Loader{}.Load(&c, []string{"API_TOKEN=a"})
+		chk.ErrorIs(err, env.ErrMissingVariable)
+		chk.False(errors.Is(err, env.ErrUnknownVariable))
+		chk.False(errors.Is(err, env.ErrInvalidVariable))
+		//
+		err = env.Loader{}.Load(c, nil)
+		chk.ErrorIs(err, set.ErrReadOnly)
+	})
+	t.Run("slice index limit", func(t *testing.T) {
+		chk := assert.New(t)
+		var c Config
+		err := env.Loader{Prefix: "APP"}.Load(&c, []string{
+			"APP_API_TOKEN=secret",
+			"APP_NAME=app",
+			fmt.Sprintf("APP_SERVERS_%v_HOST=last", env.MaxSliceIndex),
+			fmt.Sprintf("APP_SERVERS_%v_HOST=over", env.MaxSliceIndex+1),
+		})
+		var envErr *env.Error
+		chk.True(errors.As(err, &envErr))
+		chk.Equal([]string{fmt.Sprintf("APP_SERVERS_%v_HOST", env.MaxSliceIndex+1)}, envErr.Unknown)
+		chk.Len(c.Servers, env.MaxSliceIndex+1)
+		chk.Equal("last", c.Servers[env.MaxSliceIndex].Host)
+	})
+	t.Run("mapper", func(t *testing.T) {
+		chk := assert.New(t)
+		type T struct {
+			UserName string `cfg:"user"`
+		}
+		loader := env.Loader{
+			Prefix: "app",
+			Mapper: &set.Mapper{
+				Tags:      []string{"cfg"},
+				Transform: set.KebabCase,
+				Join:      ".",
+			},
+		}
+		var v T
+		chk.NoError(loader.Load(&v, []string{"app.user=bob"}))
+		chk.Equal("bob", v.UserName)
+	})
+	t.Run("os environ", func(t *testing.T) {
+		chk := assert.New(t)
+		type T struct {
+			Value string
+		}
+		os.Setenv("SET_ENV_TEST_VALUE", "from os")
+		defer os.Unsetenv("SET_ENV_TEST_VALUE")
+		var v T
+		chk.NoError(env.Load(&v, "SET_ENV_TEST"))
+		chk.Equal("from os", v.Value)
+	})
+}
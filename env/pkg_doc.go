@@ -0,0 +1,41 @@
+// Package env fills structs from environment variables using a set.Mapper and set.BoundMapping.
+//
+// Variable Names
+//
+// Variable names are the keys generated by the Mapper joined to an optional prefix.  The
+// DefaultMapper transforms field names with set.ScreamingSnakeCase and joins them with "_":
+//	type Config struct {
+//		HTTPServer struct {
+//			Port int
+//		}
+//		Debug bool
+//	}
+//	// With Prefix "APP" the variables are:
+//	//	APP_HTTP_SERVER_PORT
+//	//	APP_DEBUG
+//
+// Struct tags named env override the generated names; the option required reports the
+// variable as missing if it is not set:
+//	type Config struct {
+//		Token string `env:"API_TOKEN,required"`
+//	}
+//
+// Slices of structs are filled by index:
+//	type Config struct {
+//		Servers []struct {
+//			Host string
+//		}
+//	}
+//	// APP_SERVERS_0_HOST, APP_SERVERS_1_HOST, ...
+//
+// Indexes larger than MaxSliceIndex are unknown variables so a single variable can not grow a
+// slice without bound.
+//
+// Values are assigned with BoundMapping.Set and are coerced with the same rules as set.Value.To.
+//
+// Errors
+//
+// Load and Loader.Load return a *Error when variables are unknown, missing, or invalid; use
+// errors.As to inspect it or errors.Is with ErrUnknownVariable, ErrMissingVariable, or
+// ErrInvalidVariable.
+package env
@@ -3,15 +3,35 @@ package set
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/set/coerce"
+	"github.com/nofeaturesonlybugs/set/path"
 )
 
 var (
+	// ErrAccessorMismatch is returned by Mapper.RegisterAccessor when an Accessor does not
+	// agree with the Mapping created by the Mapper.
+	ErrAccessorMismatch = errors.New("accessor does not match mapping")
+
+	// ErrAmbiguousField is returned by BoundMapping and PreparedMapping when given field
+	// matches more than one key after normalization; see Mapper.Normalize.
+	ErrAmbiguousField = errors.New("ambiguous field")
+
+	// ErrDuplicateKey is wrapped by DuplicateKeyError.
+	ErrDuplicateKey = errors.New("duplicate key")
+
 	// ErrIndexOutOfBounds is returned when an index operation exceeds a bounds check.
 	ErrIndexOutOfBounds = errors.New("index out of bounds")
 
 	// ErrInvalidSlice is returned by NewSlice when the in coming value is not pointer-to-slice.
 	ErrInvalidSlice = errors.New("invalid slice")
 
+	// ErrLossy is returned by Value.ToStrict when an assignment would lose information.  It is
+	// the same error as coerce.ErrLossy.
+	ErrLossy = coerce.ErrLossy
+
 	// ErrNoPlan is returned when a PreparedMapping does not have a valid access plan.
 	ErrNoPlan = errors.New("no plan")
 
@@ -32,6 +52,150 @@ var (
 	ErrUnsupported = errors.New("unsupported")
 )
 
+// DuplicateKeyError is returned by Mapper.MapErr, and by Mapper.Bind and Mapper.Prepare when
+// Mapper.StrictKeys is true, if more than one field in a struct maps to the same key.
+//
+// DuplicateKeyError wraps ErrDuplicateKey.  Use errors.As to obtain the DuplicateKeyError from
+// an error returned by this package.
+type DuplicateKeyError struct {
+	// Type is the mapped type.
+	Type reflect.Type
+
+	// Duplicates contains each key that more than one field maps to in the order the keys
+	// appear in Mapping.Keys.
+	Duplicates []DuplicateKey
+}
+
+// DuplicateKey is a key that more than one field maps to.
+type DuplicateKey struct {
+	Key string
+
+	// Fields are the Go paths of the fields mapped to Key such as Address.City.  Elements of arrays
+	// and slices are written as Points[0].X or Items[#].Name.
+	Fields []string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	parts := make([]string, len(e.Duplicates))
+	for k, duplicate := range e.Duplicates {
+		parts[k] = "key [" + duplicate.Key + "] from fields [" + strings.Join(duplicate.Fields, ", ") + "]"
+	}
+	return fmt.Sprintf("%v in type %v: %v", ErrDuplicateKey, e.Type, strings.Join(parts, "; "))
+}
+
+// Has returns true if key is one of the Duplicates.
+func (e *DuplicateKeyError) Has(key string) bool {
+	for _, duplicate := range e.Duplicates {
+		if duplicate.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *DuplicateKeyError) Unwrap() error {
+	return ErrDuplicateKey
+}
+
+// FieldError describes a field that could not be assigned.  It is returned by BoundMapping.Set and
+// PreparedMapping.Set and collected in FillError.
+//
+// FieldError wraps the error that caused the failure, such as coerce.ErrInvalid or ErrUnsupported,
+// so it can be tested with errors.Is.  Use errors.As to obtain the FieldError from an error returned
+// by this package.
+type FieldError struct {
+	// Path is the Go path of the field such as Address.City.  Elements of slices and maps are
+	// written as Items[0].Name or Labels[key].
+	Path string
+
+	// Key is the key used to look up Value, such as the name or struct tag value passed to a Getter,
+	// or the mapping key passed to BoundMapping.Set or PreparedMapping.Plan.
+	Key string
+
+	// Value is the value that could not be assigned.
+	Value interface{}
+
+	// Type is the type of the field.
+	Type reflect.Type
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field [%v] key [%v] type %v: %v", e.Path, e.Key, e.Type, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FillError is returned by Value.FillAll and Value.FillByTagAll when one or more fields could not
+// be filled.
+//
+// errors.Is and errors.As test each of the Errors in turn; errors.As with a **FieldError obtains
+// the first FieldError and errors.As with a **FillError obtains all of them.
+type FillError struct {
+	// Errors contains a FieldError for each field that could not be filled in the order the
+	// fields were visited.
+	Errors []*FieldError
+}
+
+func (e *FillError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for k, err := range e.Errors {
+		parts[k] = err.Error()
+	}
+	return fmt.Sprintf("%v field(s) could not be filled: %v", len(e.Errors), strings.Join(parts, "; "))
+}
+
+// Is returns true if one of the Errors matches target.
+func (e *FillError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the Errors that matches target.
+func (e *FillError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// fillState collects the FieldErrors for Value.FillAll and Value.FillByTagAll.
+type fillState struct {
+	errs []*FieldError
+}
+
+// add appends err.
+func (s *fillState) add(err *FieldError) {
+	s.errs = append(s.errs, err)
+}
+
+// err returns nil if no errors were collected or an error wrapping a *FillError.
+func (s *fillState) err(callsite string) error {
+	if len(s.errs) == 0 {
+		return nil
+	}
+	return pkgerr{Err: &FillError{Errors: s.errs}, CallSite: callsite}
+}
+
+// setError returns an error wrapping a *FieldError for err, which occurred when assigning value to
+// the field of type dest for key.  step is the path to the field within top.
+func setError(err error, callsite string, top reflect.Type, key string, step path.ReflectPath, dest reflect.Type, value interface{}) error {
+	return pkgerr{
+		Err:      &FieldError{Path: step.Name(top), Key: key, Value: value, Type: dest, Err: err},
+		CallSite: callsite,
+	}
+}
+
 // pkgerr is a custom error type to provide more context for sentinal errors.
 type pkgerr struct {
 	Err      error
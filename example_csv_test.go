@@ -12,7 +12,7 @@ import (
 )
 
 // This example demonstrates how Mapper might be used to create a general
-// purpose CSV unmarshaler.
+// purpose CSV unmarshaler; package csv contains a complete Decoder and Encoder.
 
 // CSVUnmarshaler is a general purpose CSV unmarshaler.
 type CSVUnmarshaler struct {
@@ -1,7 +1,11 @@
 package set
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
+
+	"github.com/nofeaturesonlybugs/set/coerce"
 )
 
 // Getter returns a value by name.
@@ -10,6 +14,15 @@ type Getter interface {
 	Get(name string) interface{}
 }
 
+// KeyGetter is a Getter that can also list the names it has values for.
+//
+// Value.Fill and Value.FillByTag require a KeyGetter to populate fields that are maps.
+type KeyGetter interface {
+	Getter
+	// Keys returns the names with values.
+	Keys() []string
+}
+
 // GetterFunc casts a function into a Getter.
 type GetterFunc func(name string) interface{}
 
@@ -20,7 +33,9 @@ func (me GetterFunc) Get(name string) interface{} {
 
 // MapGetter accepts a map and returns a Getter.
 //
-// Map keys must be string or interface{}.
+// Map keys must be string or interface{}.  When m is such a map the returned Getter is
+// also a KeyGetter.  Keys of interface{} maps that are not strings, such as the int keys
+// decoded from YAML, are named by formatting them with coerce.String.
 func MapGetter(m interface{}) Getter {
 	rv := GetterFunc(func(key string) interface{} { return nil })
 	//
@@ -33,24 +48,71 @@ func MapGetter(m interface{}) Getter {
 		return rv
 	}
 	//
-	rv = GetterFunc(func(key string) interface{} {
-		if reflected := RV.MapIndex(reflect.ValueOf(key)); reflected.IsValid() {
-			value := V(reflected.Interface())
-			if value.IsMap {
-				return MapGetter(reflected.Interface())
-			} else if value.IsSlice && value.ElemTypeInfo.IsMap {
-				getterSlice := []Getter{}
-				for k, max := 0, value.WriteValue.Len(); k < max; k++ {
-					getterSlice = append(getterSlice, MapGetter(value.WriteValue.Index(k).Interface()))
-				}
-				return getterSlice
-			} else {
-				return reflected.Interface()
+	return mapGetter{RV}
+}
+
+// mapGetter is the KeyGetter returned by MapGetter.
+type mapGetter struct {
+	RV reflect.Value
+}
+
+// Get accepts a name and returns the value.
+func (me mapGetter) Get(key string) interface{} {
+	reflected := me.RV.MapIndex(reflect.ValueOf(key))
+	if !reflected.IsValid() && me.RV.Type().Key().Kind() == reflect.Interface {
+		for iter := me.RV.MapRange(); iter.Next(); {
+			if k := iter.Key().Elem(); k.Kind() != reflect.String && formatKey(k) == key {
+				reflected = iter.Value()
+				break
+			}
+		}
+	}
+	if reflected.IsValid() {
+		value := V(reflected.Interface())
+		if value.IsMap {
+			return MapGetter(reflected.Interface())
+		} else if value.IsSlice && value.ElemTypeInfo.IsMap {
+			getterSlice := []Getter{}
+			for k, max := 0, value.WriteValue.Len(); k < max; k++ {
+				getterSlice = append(getterSlice, MapGetter(value.WriteValue.Index(k).Interface()))
 			}
+			return getterSlice
 		} else {
-			return nil
+			return reflected.Interface()
 		}
-	})
-	//
-	return rv
+	} else {
+		return nil
+	}
+}
+
+// Keys returns the map keys in sorted order; keys that are not strings are formatted with
+// coerce.String and keys that format the same are only returned once.
+func (me mapGetter) Keys() []string {
+	keys := make([]string, 0, me.RV.Len())
+	for _, key := range me.RV.MapKeys() {
+		if key.Kind() == reflect.Interface {
+			key = key.Elem()
+		}
+		keys = append(keys, formatKey(key))
+	}
+	sort.Strings(keys)
+	for k := len(keys) - 1; k > 0; k-- {
+		if keys[k] == keys[k-1] {
+			keys = append(keys[:k], keys[k+1:]...)
+		}
+	}
+	return keys
+}
+
+// formatKey returns the map key as a string; a nil key is empty and keys coerce.String can not
+// format, such as structs, are formatted with fmt.
+func formatKey(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return key.String()
+	} else if !key.IsValid() {
+		return ""
+	} else if s, err := coerce.String(key.Interface()); err == nil {
+		return s
+	}
+	return fmt.Sprint(key.Interface())
 }
@@ -20,3 +20,46 @@ func TestMapGetter_codeCoverage(t *testing.T) {
 		chk.Nil(g.Get("foo"))
 	}
 }
+
+func TestMapGetter_Keys(t *testing.T) {
+	chk := assert.New(t)
+	g := set.MapGetter(map[interface{}]int{"b": 1, "a": 2, 3: 3})
+	keyGetter, ok := g.(set.KeyGetter)
+	chk.True(ok)
+	chk.Equal([]string{"3", "a", "b"}, keyGetter.Keys())
+	chk.Equal(2, keyGetter.Get("a"))
+	chk.Equal(3, keyGetter.Get("3"))
+	chk.Nil(keyGetter.Get("4"))
+	//
+	// Keys that are not strings are formatted; a string key is preferred by Get when both
+	// format the same.
+	mixed := map[interface{}]interface{}{
+		"name":           "x",
+		1:                "one",
+		"1":              "string one",
+		2.5:              "float",
+		true:             "bool",
+		struct{}{}:       "struct",
+		interface{}(nil): "nil",
+	}
+	keyGetter = set.MapGetter(mixed).(set.KeyGetter)
+	chk.Equal([]string{"", "1", "2.5", "name", "true", "{}"}, keyGetter.Keys())
+	chk.Equal("string one", keyGetter.Get("1"))
+	chk.Equal("float", keyGetter.Get("2.5"))
+	chk.Equal("bool", keyGetter.Get("true"))
+	chk.Equal("struct", keyGetter.Get("{}"))
+	chk.Equal("nil", keyGetter.Get(""))
+	//
+	type T struct {
+		Ports map[int]string
+	}
+	var dst T
+	err := set.V(&dst).Fill(set.MapGetter(map[string]interface{}{
+		"Ports": map[interface{}]interface{}{80: "http", 443: "https"},
+	}))
+	chk.NoError(err)
+	chk.Equal(map[int]string{80: "http", 443: "https"}, dst.Ports)
+	//
+	_, ok = set.MapGetter(42).(set.KeyGetter)
+	chk.False(ok)
+}
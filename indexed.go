@@ -0,0 +1,121 @@
+package set
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/set/path"
+)
+
+// IndexPlaceholder is the name segment used in place of a slice index when a Mapper has
+// IndexSlices enabled.
+//
+//	type T struct {
+//		Items []struct {
+//			Name string
+//		}
+//	}
+//	// Mapping.Keys contains: Items_#_Name
+//	// BoundMapping.Set accepts: Items_0_Name, Items_1_Name, ...
+const IndexPlaceholder = "#"
+
+// DefaultMaxSliceIndex is the largest slice index accepted in keys when Mapper.MaxSliceIndex
+// is zero.
+const DefaultMaxSliceIndex = 1000
+
+// indexedKeys matches keys containing slice indexes to the patterns in a Mapping.
+type indexedKeys struct {
+	// join is Mapper.Join and maxIndex is Mapper.MaxSliceIndex or DefaultMaxSliceIndex; a
+	// negative maxIndex is no limit.
+	join     string
+	maxIndex int
+
+	// slices contains the patterns naming slice fields; a name segment following one of
+	// these patterns is an index.
+	slices map[string]struct{}
+}
+
+// newIndexedKeys returns an empty *indexedKeys for the Mapper.
+func newIndexedKeys(me *Mapper) *indexedKeys {
+	maxIndex := me.MaxSliceIndex
+	if maxIndex == 0 {
+		maxIndex = DefaultMaxSliceIndex
+	}
+	return &indexedKeys{
+		join:     me.Join,
+		maxIndex: maxIndex,
+		slices:   map[string]struct{}{},
+	}
+}
+
+// add records pattern as the name of a slice field.
+func (ix *indexedKeys) add(pattern string) {
+	ix.slices[pattern] = struct{}{}
+}
+
+// merge records the slice patterns in other with prefix prepended.
+func (ix *indexedKeys) merge(prefix string, other *indexedKeys) {
+	if other == nil {
+		return
+	}
+	for pattern := range other.slices {
+		ix.slices[prefix+ix.join+pattern] = struct{}{}
+	}
+}
+
+// match converts key into a pattern by replacing each index segment with IndexPlaceholder;
+// the replaced indexes are returned in order.  ok is false if key does not contain any indexes
+// or an index exceeds maxIndex.  Indexes with leading zeros are not recognized so that each
+// element has exactly one key.
+func (ix *indexedKeys) match(key string) (pattern string, indexes []int, ok bool) {
+	if ix == nil || len(ix.slices) == 0 || ix.join == "" {
+		return "", nil, false
+	}
+	var b strings.Builder
+	for rest := key; rest != ""; {
+		segment := rest
+		if n := strings.Index(rest, ix.join); n != -1 {
+			segment, rest = rest[:n], rest[n+len(ix.join):]
+		} else {
+			rest = ""
+		}
+		if _, isSlice := ix.slices[b.String()]; isSlice && isIndex(segment) {
+			n, err := strconv.Atoi(segment)
+			if err != nil || (ix.maxIndex > 0 && n > ix.maxIndex) {
+				return "", nil, false
+			}
+			indexes, segment = append(indexes, n), IndexPlaceholder
+		}
+		if b.Len() > 0 {
+			b.WriteString(ix.join)
+		}
+		b.WriteString(segment)
+	}
+	return b.String(), indexes, len(indexes) > 0
+}
+
+// lookup returns the path.ReflectPath for key when it contains slice indexes.
+func (ix *indexedKeys) lookup(paths map[string]path.ReflectPath, key string) (path.ReflectPath, bool) {
+	pattern, indexes, ok := ix.match(key)
+	if !ok {
+		return path.ReflectPath{}, false
+	}
+	p, ok := paths[pattern]
+	if !ok {
+		return path.ReflectPath{}, false
+	}
+	return p.Indexed(indexes)
+}
+
+// isIndex returns true if s is non-empty, contains only the digits 0-9, and has no leading zeros.
+func isIndex(s string) bool {
+	if s == "" || (s[0] == '0' && s != "0") {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
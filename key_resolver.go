@@ -0,0 +1,115 @@
+package set
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nofeaturesonlybugs/set/path"
+)
+
+// NormalizeKey is a function suitable for Mapper.Normalize; it converts key to lower case and
+// removes every rune that is not a letter or digit.
+//
+//	NormalizeKey("User ID") // userid
+//	NormalizeKey("user_id") // userid
+//	NormalizeKey("USERID")  // userid
+func NormalizeKey(key string) string {
+	var b strings.Builder
+	b.Grow(len(key))
+	for _, r := range key {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// keyResolver resolves keys that do not exactly match a key in a Mapping; it is shared by the
+// BoundMapping and PreparedMapping instances created from the Mapping.
+type keyResolver struct {
+	indexed *indexedKeys
+
+	// normalize is Mapper.Normalize and normalized maps the result of normalize to the keys
+	// in the Mapping that produce it.
+	normalize  func(string) string
+	normalized map[string][]string
+}
+
+// newKeyResolver returns a *keyResolver for mapping or nil if the Mapper does not need one.
+func newKeyResolver(me *Mapper, mapping *Mapping) *keyResolver {
+	if mapping.indexed == nil && me.Normalize == nil {
+		return nil
+	}
+	rv := &keyResolver{
+		indexed:   mapping.indexed,
+		normalize: me.Normalize,
+	}
+	if rv.normalize != nil {
+		rv.normalized = map[string][]string{}
+		for _, key := range mapping.Keys {
+			if throughSlice(mapping.ReflectPaths[key]) {
+				// Patterns such as Items_#_Name require an index and are never normalized.
+				continue
+			}
+			normalized := rv.normalize(key)
+			if !containsString(rv.normalized[normalized], key) {
+				rv.normalized[normalized] = append(rv.normalized[normalized], key)
+			}
+		}
+	}
+	return rv
+}
+
+// lookup returns the path.ReflectPath for key when it is not found in paths.
+//
+// Keys containing slice indexes are tried first followed by the normalized key.  The error is
+// a pkgerr wrapping ErrUnknownField or ErrAmbiguousField.
+func (r *keyResolver) lookup(paths map[string]path.ReflectPath, key string, top reflect.Type, callsite string) (path.ReflectPath, error) {
+	if r != nil {
+		if step, ok := r.indexed.lookup(paths, key); ok {
+			return step, nil
+		}
+		if r.normalize != nil {
+			switch keys := r.normalized[r.normalize(key)]; len(keys) {
+			case 0:
+			case 1:
+				return paths[keys[0]], nil
+			default:
+				keys = append([]string(nil), keys...)
+				sort.Strings(keys)
+				return path.ReflectPath{}, pkgerr{
+					Err:      ErrAmbiguousField,
+					CallSite: callsite,
+					Context:  "field [" + key + "] matches [" + strings.Join(keys, ", ") + "] in type " + top.String(),
+				}
+			}
+		}
+	}
+	return path.ReflectPath{}, pkgerr{
+		Err:      ErrUnknownField,
+		CallSite: callsite,
+		Context:  "field [" + key + "] not found in type " + top.String(),
+	}
+}
+
+// throughSlice returns true if p reaches its field through an element of a slice.
+func throughSlice(p path.ReflectPath) bool {
+	for cur := &p; cur != nil && cur.Elem != nil; cur = cur.Elem.Path {
+		if cur.Elem.Slice {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString returns true if s is in slice.
+func containsString(slice []string, s string) bool {
+	for _, str := range slice {
+		if str == s {
+			return true
+		}
+	}
+	return false
+}
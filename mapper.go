@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -25,11 +26,14 @@ type Mapping struct {
 	// Keys contains the names generated by the Mapper that created this Mapping.
 	//
 	// See Mapper documentation for information on controlling how and what names
-	// are generated.
+	// are generated.  Keys for elements of slices contain IndexPlaceholder; see
+	// Mapper.IndexSlices.
 	Keys []string
 
 	// Indeces contains each mapped field's index as an int slice ([]int) such as
-	// would be appropriate for passing to reflect.Value.FieldByIndex([]int).
+	// would be appropriate for passing to reflect.Value.FieldByIndex([]int).  For keys
+	// that refer to an element of an array or slice the index is that of the array or
+	// slice field.
 	//
 	// However bear in mind reflect.Value.FieldByIndex([]int) essentially requires that
 	// none of the intermediate fields described by the index are pointers or nil.
@@ -52,8 +56,40 @@ type Mapping struct {
 	// for a path than a plain []int.
 	ReflectPaths map[string]path.ReflectPath
 
+	// Options contains the options parsed from the struct tag of each mapped field; keys
+	// whose struct tag has no options are not present.
+	//
+	// Struct tags are parsed in the same manner as encoding/json: the tag value is split on
+	// commas where the first element is the name and the remaining elements are options.
+	//	type T struct {
+	//		Name string `json:"name,omitempty,required"`
+	//		Skip string `json:"-"` // Not mapped.
+	//	}
+	//	// Mapping.Options["name"] is TagOptions{"omitempty", "required"}
+	Options map[string]TagOptions
+
+	// Defaults contains the value of the struct tag named by Mapper.DefaultTag for each mapped
+	// field that has one; see BoundMapping.ApplyDefaults.  Keys that refer to elements of slices
+	// are not present.
+	Defaults map[string]string
+
 	// HasPointers will be true if any of the pathways traverse a field that is a pointer.
 	HasPointers bool
+
+	// indexed is non-nil when the Mapper has IndexSlices enabled and the mapped type
+	// contains slices of structs.
+	indexed *indexedKeys
+
+	// resolver is passed to BoundMapping and PreparedMapping.
+	resolver *keyResolver
+
+	// fieldPaths contains the Go field paths mapped to each key and is only used while mapping;
+	// duplicates is non-nil if any key has more than one field path.
+	fieldPaths map[string][]string
+	duplicates *DuplicateKeyError
+
+	// defaultKeys contains the keys in Defaults in the order of Keys.
+	defaultKeys []string
 }
 
 // Mapper creates Mapping instances from structs and struct hierarchies.
@@ -91,6 +127,10 @@ type Mapper struct {
 	// If most of your db and json names match but you occasionally want to override the json
 	// struct tag value with the db struct tag value you could set this member to:
 	//	[]string{ "db", "json" } // struct tag `db` used before struct tag `json`
+	//
+	// Only the first struct tag found is used.  Its value is split on commas into a name followed
+	// by options; see Mapping.Options.  If the name is empty the field name is used and if the
+	// value is "-" the field is not mapped.
 	Tags []string
 
 	// When TaggedFieldsOnly is true the Map() method only maps struct fields that have tags
@@ -98,17 +138,120 @@ type Mapper struct {
 	// mapped.
 	TaggedFieldsOnly bool
 
+	// DefaultTag is the name of a struct tag containing default values for fields, such as
+	// `default:"30s"`.  The values are collected in Mapping.Defaults and assigned to zero fields
+	// by BoundMapping.ApplyDefaults.  When DefaultTag is empty no defaults are read.
+	DefaultTag string
+
+	// When PromoteEmbedded is true the fields of embedded structs, or embedded pointers to
+	// structs, are promoted into the parent with the same rules encoding/json uses.  Embedded
+	// structs are not part of the generated name unless they have a struct tag naming them.
+	// When more than one field has the same name the field nested the fewest embedded structs
+	// deep wins; if there is a tie the field with a struct tag wins; otherwise none of the
+	// tied fields are mapped.
+	//
+	// With Tags set to []string{"json"} the generated names are the same as the keys created by
+	// json.Marshal for structs without nested struct fields.
+	PromoteEmbedded bool
+
+	// Normalize is an optional function used by BoundMapping and PreparedMapping.Plan when a
+	// key does not exactly match a key in the Mapping.  Normalize is applied to every key when
+	// the Mapping is created and to the given key during lookup; if exactly one key matches then
+	// it is used and if more than one key matches an error wrapping ErrAmbiguousField is returned.
+	//
+	// Keys containing slice indexes (see IndexSlices) are not normalized.
+	//
+	// See NormalizeKey for a function that ignores case and separators.
+	Normalize func(string) string
+
+	// When StrictKeys is true Bind, Prepare, and their ReadOnly variants return an error wrapping a *DuplicateKeyError
+	// if more than one field in the struct maps to the same key.  This can occur when names
+	// are altered by Transform, chosen by struct tags, or merged by Elevated.  When StrictKeys
+	// is false the last field mapped to the key is used.
+	//
+	// See also MapErr.
+	StrictKeys bool
+
 	// Join specifies the string used to join generated names as nesting increases.
 	Join string
 
 	// If set this function is called when the struct field name is being used as
 	// the generated name.  This function can perform string alteration to force all
 	// names to lowercase, string replace, etc.
+	//
+	// This package provides SnakeCase, ScreamingSnakeCase, KebabCase, CamelCase, and LowerCase;
+	// see JoinWords to create others.  Pair a transform with a matching Join:
+	//	&set.Mapper{Transform: set.SnakeCase, Join: "_"} // HTTPServer.ID becomes http_server_id
 	Transform func(string) string
 
+	// MaxDepth controls how far self-referencing or mutually recursive struct types are
+	// expanded.  When a struct type appears again as one of its own descendants the pathway
+	// is expanded at most MaxDepth additional times.
+	//	type Node struct {
+	//		Name string
+	//		Next *Node
+	//	}
+	//	// MaxDepth=0 maps: Name
+	//	// MaxDepth=1 maps: Name, Next_Name
+	//	// MaxDepth=2 maps: Name, Next_Name, Next_Next_Name
+	MaxDepth int
+
+	// Converters is an optional ConverterRegistry consulted by BoundMapping.Set and
+	// PreparedMapping.Set before the global Converters registry and before any built-in
+	// type coercion.
+	Converters *ConverterRegistry
+
+	// When SQLAdapters is true the BoundMapping and PreparedMapping instances created by this
+	// Mapper adapt their Assignables and Fields methods for use with database/sql.
+	//
+	// Assignables returns values implementing sql.Scanner instead of pointers to fields.  Scanned
+	// values are assigned with the same rules as Value.To and NULL sets pointer fields to nil and
+	// other fields to their zero value.  Fields that implement sql.Scanner themselves are scanned
+	// directly.  This allows nullable columns to be scanned into plain string, int, etc. fields.
+	//
+	// Fields returns values suitable as query arguments; see driver.Value.  Nil pointers are
+	// returned as nil (NULL) and fields implementing driver.Valuer are returned as the result of
+	// their Value method.
+	SQLAdapters bool
+
+	// When IndexSlices is true fields that are slices of structs are mapped with an index
+	// segment in their names.  Keys in the Mapping contain IndexPlaceholder in place of the
+	// index and describe every element of the slice.
+	//	type Server struct {
+	//		Host string
+	//	}
+	//	type Config struct {
+	//		Servers []Server
+	//	}
+	//	// Mapping.Keys contains: Servers_#_Host
+	//
+	// BoundMapping and PreparedMapping accept the same keys with the placeholder replaced by
+	// an index such as Servers_0_Host or Servers_1_Host.  Slices are grown as needed to reach
+	// the index.  Join must not be empty for indexes to be recognized.
+	IndexSlices bool
+
+	// When IndexSlices is true keys containing an index larger than MaxSliceIndex are unknown
+	// fields; this limits how large a slice can grow when keys come from an untrusted source.
+	// When MaxSliceIndex is zero DefaultMaxSliceIndex is used and when it is negative indexes
+	// are not limited.
+	MaxSliceIndex int
+
+	// When UnsafeOffsets is true the BoundMapping and PreparedMapping instances created by this
+	// Mapper access fields with pointer arithmetic using the offsets computed by path.Stat
+	// instead of walking reflect.Value.Field.
+	//
+	// Only fields whose type is a built-in scalar, such as int, float64, or string, or time.Time
+	// are accessed this way and Set only uses it when the value has exactly the field's type.
+	// Other fields, values requiring coercion, and elements of arrays or slices use reflect as
+	// usual.  SQLAdapters takes precedence when reading with Fields or ReadFields.
+	UnsafeOffsets bool
+
 	//
 	// NB  sync.Map outperformed map+RWMutex in benchmarks.
 	known sync.Map
+
+	// accessors contains the Accessor registered for a struct type; see RegisterAccessor.
+	accessors sync.Map
 }
 
 // DefaultMapper joins names by "_" but performs no other modifications.
@@ -124,6 +267,9 @@ var DefaultMapper = &Mapper{
 //
 // See documentation for BoundMapping for more details.
 //
+// If an Accessor is registered for the struct type with RegisterAccessor the BoundMapping uses
+// it to access fields without reflect.
+//
 // I must be an addressable type.
 func (me *Mapper) Bind(I interface{}) (BoundMapping, error) {
 	var value reflect.Value
@@ -147,11 +293,56 @@ func (me *Mapper) Bind(I interface{}) (BoundMapping, error) {
 		return BoundMapping{err: err}, err
 	}
 	mapping := me.Map(I)
+	if me.StrictKeys && mapping.duplicates != nil {
+		err := pkgerr{Err: mapping.duplicates, CallSite: "Mapper.Bind"}
+		return BoundMapping{err: err}, err
+	}
 	//
 	rv := BoundMapping{
-		top:   typ,
-		value: value,
-		paths: mapping.ReflectPaths,
+		top:         typ,
+		value:       value,
+		paths:       mapping.ReflectPaths,
+		resolver:    mapping.resolver,
+		converters:  me.Converters,
+		sqlAdapters: me.SQLAdapters,
+		defaults:    mapping.Defaults,
+		defaultKeys: mapping.defaultKeys,
+	}
+	if accessor, ok := me.accessors.Load(value.Type()); ok {
+		rv.accessor, rv.ptr = accessor.(Accessor), value.Addr().Interface()
+	}
+	return rv, nil
+}
+
+// BindReadOnly creates a BoundMapping bound to I for reading with ReadFields.
+//
+// Unlike Bind, I can be a struct that is not addressable or a pointer or pointer chain to a
+// struct that is nil.  The returned BoundMapping never allocates or modifies the bound value;
+// Rebind accepts values of the same type as I and all methods except ReadFields, Rebind, and
+// Copy return an error wrapping ErrReadOnly.
+func (me *Mapper) BindReadOnly(I interface{}) (BoundMapping, error) {
+	var value reflect.Value
+	switch sw := I.(type) {
+	case reflect.Value:
+		value = sw
+	default:
+		value = reflect.ValueOf(I)
+	}
+	mapping := me.Map(I)
+	if me.StrictKeys && mapping.duplicates != nil {
+		err := pkgerr{Err: mapping.duplicates, CallSite: "Mapper.BindReadOnly"}
+		return BoundMapping{err: err}, err
+	}
+	//
+	rv := BoundMapping{
+		top:         value.Type(),
+		value:       readOnlyValue(value),
+		err:         pkgerr{Err: ErrReadOnly, CallSite: "Mapper.BindReadOnly", Hint: "use BoundMapping.ReadFields"},
+		readOnly:    true,
+		paths:       mapping.ReflectPaths,
+		resolver:    mapping.resolver,
+		converters:  me.Converters,
+		sqlAdapters: me.SQLAdapters,
 	}
 	return rv, nil
 }
@@ -182,26 +373,106 @@ func (me *Mapper) Map(T interface{}) Mapping {
 		return *(rv.(*Mapping))
 	}
 	//
+	// active counts the struct types currently being scanned; it is used to stop expanding
+	// recursive types according to MaxDepth and mirrors the logic in path.StatDepth.
+	rv := me.mapType(typeInfo, map[reflect.Type]int{})
+	rv.fieldPaths = nil
+	rv.resolver = newKeyResolver(me, rv)
+	me.known.Store(typeInfo.Type, rv)
+	//
+	return *rv
+}
+
+// MapErr is the same as Map except it also returns an error wrapping a *DuplicateKeyError if
+// more than one field in T maps to the same key.  The Mapping is returned even when there is
+// an error.
+func (me *Mapper) MapErr(T interface{}) (Mapping, error) {
+	rv := me.Map(T)
+	if rv.duplicates != nil {
+		return rv, pkgerr{Err: rv.duplicates, CallSite: "Mapper.MapErr"}
+	}
+	return rv, nil
+}
+
+// mapType creates a new Mapping for the struct type described by typeInfo.  active is shared
+// with the calls made for arrays of structs so that recursive types obey MaxDepth.
+func (me *Mapper) mapType(typeInfo TypeInfo, active map[reflect.Type]int) *Mapping {
 	// Create a more formal mapping from subpackage path.
-	paths := path.Stat(typeInfo.Type)
+	var paths path.Tree
+	if me.PromoteEmbedded {
+		paths = path.StatEmbedded(typeInfo.Type, me.MaxDepth)
+	} else {
+		paths = path.StatDepth(typeInfo.Type, me.MaxDepth)
+	}
 	//
 	rv := &Mapping{
 		Keys:         []string{},
 		Indeces:      map[string][]int{},
 		StructFields: map[string]reflect.StructField{},
 		ReflectPaths: map[string]path.ReflectPath{},
+		Options:      map[string]TagOptions{},
+		Defaults:     map[string]string{},
+		fieldPaths:   map[string][]string{},
 	}
 	//
-	// add adds an entry to rv.
-	add := func(name string, index []int, field reflect.StructField, path path.Path) {
+	// add adds an entry to rv; fieldPath is the Go path to the field used to report duplicates.
+	add := func(name string, fieldPath string, index []int, field reflect.StructField, path path.ReflectPath, hasPointer bool) {
 		rv.Keys = append(rv.Keys, name)
+		rv.fieldPaths[name] = append(rv.fieldPaths[name], fieldPath)
 		rv.Indeces[name] = index
 		rv.StructFields[name] = field
-		rv.ReflectPaths[name] = path.ReflectPath()
-		if len(path.PathwayIndex) > 1 {
+		rv.ReflectPaths[name] = path
+		delete(rv.Defaults, name)
+		if tag := parseStructTag(field, me.Tags); len(tag.options) > 0 {
+			rv.Options[name] = tag.options
+		}
+		if hasPointer {
 			rv.HasPointers = true
 		}
 	}
+	// addDefault records value as the default for name; it must be called after add.
+	addDefault := func(name string, value string) {
+		if !containsString(rv.defaultKeys, name) {
+			rv.defaultKeys = append(rv.defaultKeys, name)
+		}
+		rv.Defaults[name] = value
+	}
+	addPath := func(name string, index []int, field reflect.StructField, path path.Path) {
+		reflectPath := path.ReflectPath()
+		if me.UnsafeOffsets && unsafeLeaf(path.PathwayOffsets) {
+			reflectPath.Offsets = path.PathwayOffsets
+		}
+		add(name, path.PathwayName, index, field, reflectPath, len(path.PathwayIndex) > 1)
+		if value, ok := field.Tag.Lookup(me.DefaultTag); ok && me.DefaultTag != "" {
+			addDefault(name, value)
+		}
+	}
+	// addElems adds the entries in elemMapping for the element of an array or slice.
+	addElems := func(elemName string, elemPath string, index []int, p path.Path, elem path.ReflectElem, hasPointer bool, elemMapping *Mapping) {
+		seen := map[string]int{}
+		for _, key := range elemMapping.Keys {
+			elemReflectPath, fieldPath := p.ReflectPath(), elemMapping.ReflectPaths[key]
+			elem := elem
+			elem.Path = &fieldPath
+			elemReflectPath.Elem = &elem
+			goPath := elemPath + "." + elemMapping.fieldPaths[key][seen[key]]
+			seen[key]++
+			add(elemName+me.Join+key, goPath, index, elemMapping.StructFields[key], elemReflectPath, hasPointer || elemMapping.HasPointers)
+			if value, ok := elemMapping.Defaults[key]; ok && !elem.Slice {
+				addDefault(elemName+me.Join+key, value)
+			}
+		}
+	}
+	// addIndexed records pattern as a slice and merges the slices from an element mapping.
+	addIndexed := func(pattern string, prefix string, elemMapping *Mapping) {
+		if rv.indexed == nil && (pattern != "" || elemMapping.indexed != nil) {
+			rv.indexed = newIndexedKeys(me)
+		}
+		if pattern != "" {
+			rv.indexed.add(pattern)
+		}
+		rv.indexed.merge(prefix, elemMapping.indexed)
+	}
 	//
 	// NB  fullpath is used to refer back into paths to obtain a path.Path for a mapped pathway.
 	//     Practically this is a bit slower than coalescing the logic of path.Stat into
@@ -213,63 +484,115 @@ func (me *Mapper) Map(T interface{}) Mapping {
 		if fullpath != "" {
 			fullpath = fullpath + "."
 		}
-		for k, field := range typeInfo.StructFields {
-			if field.PkgPath != "" {
-				continue
-			}
-			fieldTypeInfo := TypeCache.StatType(field.Type)
-			if me.Ignored.Has(fieldTypeInfo.Type) {
-				continue
-			}
-			//
-			name, hadTag := "", false
-			if !me.Elevated.Has(fieldTypeInfo.Type) {
-				for _, tagName := range append(me.Tags, "") {
-					if tagValue, ok := field.Tag.Lookup(tagName); ok {
-						name, hadTag = tagValue, true
-						break
-					} else if tagName == "" {
-						name = field.Name
-						if me.Transform != nil {
-							name = me.Transform(name)
-						}
-						break
-					}
-				}
-			}
-			// When tagged fields are required but name was not set via tag.
-			if me.TaggedFieldsOnly && !hadTag {
-				continue
-			}
-			//
+		active[typeInfo.Type]++
+		defer func() { active[typeInfo.Type]-- }()
+		for _, f := range me.fields(typeInfo) {
+			field, fieldTypeInfo, name, fieldPath := f.field, f.typeInfo, f.name, fullpath+f.path
 			if prefix != "" && name != "" {
 				name = prefix + me.Join + name
 			} else if prefix != "" {
 				name = prefix
 			}
-			nameIndeces := append(indeces, k)
+			// NB  Copy indeces so sibling pathways do not share (and overwrite) the same backing array.
+			nameIndeces := append(append([]int(nil), indeces...), f.index...)
 			if _, ok := mapperTreatAsScalar[fieldTypeInfo.Type]; ok {
-				add(name, nameIndeces, field, paths.Leaves[fullpath+field.Name])
+				addPath(name, nameIndeces, field, paths.Leaves[fieldPath])
 			} else if _, ok = me.TreatAsScalar[fieldTypeInfo.Type]; ok {
 				// When the type is treated as scalar first search Branches and then Leaves
 				// for the associated path information.
-				if p, ok := paths.Branches[fullpath+field.Name]; ok {
-					add(name, nameIndeces, field, p)
-				} else if p, ok = paths.Leaves[fullpath+field.Name]; ok {
-					add(name, nameIndeces, field, p)
+				if p, ok := paths.Branches[fieldPath]; ok {
+					addPath(name, nameIndeces, field, p)
+				} else if p, ok = paths.Leaves[fieldPath]; ok {
+					addPath(name, nameIndeces, field, p)
 				}
 			} else if fieldTypeInfo.IsStruct {
-				scan(fieldTypeInfo, nameIndeces, name, fullpath+field.Name)
+				if active[fieldTypeInfo.Type] > me.MaxDepth {
+					continue
+				}
+				scan(fieldTypeInfo, nameIndeces, name, fieldPath)
 			} else if fieldTypeInfo.IsScalar {
-				add(name, nameIndeces, field, paths.Leaves[fullpath+field.Name])
+				addPath(name, nameIndeces, field, paths.Leaves[fieldPath])
+			} else if fieldTypeInfo.IsArray {
+				// The array is mapped as a whole and then each element is mapped with its index
+				// appended to the name.  Elements that are structs are mapped recursively.  Byte
+				// arrays such as [16]byte hashes are only mapped as a whole.
+				p := paths.Leaves[fieldPath]
+				addPath(name, nameIndeces, field, p)
+				if isByteArray(fieldTypeInfo) {
+					continue
+				}
+				//
+				elemTypeInfo := TypeCache.StatType(fieldTypeInfo.ElemType)
+				var elemMapping *Mapping
+				if me.isScalar(elemTypeInfo) {
+					// Elements are mapped directly.
+				} else if elemTypeInfo.IsStruct && active[elemTypeInfo.Type] <= me.MaxDepth {
+					elemMapping = me.mapType(elemTypeInfo, active)
+				} else {
+					continue
+				}
+				hasPointer := len(p.PathwayIndex) > 1 || field.Type.Kind() == reflect.Ptr || fieldTypeInfo.ElemType.Kind() == reflect.Ptr
+				for n, size := 0, fieldTypeInfo.Type.Len(); n < size; n++ {
+					elemName := strconv.Itoa(n)
+					if name != "" {
+						elemName = name + me.Join + elemName
+					}
+					goPath := fieldPath + "[" + strconv.Itoa(n) + "]"
+					if elemMapping == nil {
+						elemPath := p.ReflectPath()
+						elemPath.Elem = &path.ReflectElem{Index: n}
+						add(elemName, goPath, nameIndeces, field, elemPath, hasPointer)
+						continue
+					}
+					addElems(elemName, goPath, nameIndeces, p, path.ReflectElem{Index: n}, hasPointer, elemMapping)
+					addIndexed("", elemName, elemMapping)
+				}
+			} else if fieldTypeInfo.IsSlice && me.IndexSlices && name != "" {
+				// Slices of structs are mapped once with IndexPlaceholder in place of the index;
+				// the index is supplied in the key given to BoundMapping or PreparedMapping.
+				elemTypeInfo := TypeCache.StatType(fieldTypeInfo.ElemType)
+				if !elemTypeInfo.IsStruct || me.isScalar(elemTypeInfo) || active[elemTypeInfo.Type] > me.MaxDepth {
+					continue
+				}
+				elemMapping := me.mapType(elemTypeInfo, active)
+				p := paths.Leaves[fieldPath]
+				pattern := name + me.Join + IndexPlaceholder
+				addElems(pattern, fieldPath+"["+IndexPlaceholder+"]", nameIndeces, p, path.ReflectElem{Slice: true}, true, elemMapping)
+				addIndexed(name, pattern, elemMapping)
 			}
 		}
 	}
-	// Scan and assign the result to our known types.
 	scan(typeInfo, []int(nil), "", "")
-	me.known.Store(typeInfo.Type, rv)
 	//
-	return *rv
+	// Report keys with more than one field in the order they first appear.
+	for _, key := range rv.Keys {
+		fieldPaths := rv.fieldPaths[key]
+		if len(fieldPaths) < 2 {
+			continue
+		} else if rv.duplicates == nil {
+			rv.duplicates = &DuplicateKeyError{Type: typeInfo.Type}
+		} else if rv.duplicates.Has(key) {
+			continue
+		}
+		rv.duplicates.Duplicates = append(rv.duplicates.Duplicates, DuplicateKey{Key: key, Fields: fieldPaths})
+	}
+	return rv
+}
+
+// isScalar returns true if the type described by typeInfo is mapped as a single value.
+func (me *Mapper) isScalar(typeInfo TypeInfo) bool {
+	if _, ok := mapperTreatAsScalar[typeInfo.Type]; ok {
+		return true
+	} else if _, ok = me.TreatAsScalar[typeInfo.Type]; ok {
+		return true
+	}
+	return typeInfo.IsScalar
+}
+
+// isByteArray returns true if typeInfo describes an array of bytes; byte arrays are mapped as a
+// single value instead of by element.
+func isByteArray(typeInfo TypeInfo) bool {
+	return typeInfo.IsArray && typeInfo.ElemType.Kind() == reflect.Uint8
 }
 
 // Prepare creates a PreparedMapping that is initially bound to I.
@@ -304,18 +627,460 @@ func (me *Mapper) Prepare(I interface{}) (PreparedMapping, error) {
 	}
 	//
 	mapping := me.Map(I)
+	if me.StrictKeys && mapping.duplicates != nil {
+		err := pkgerr{Err: mapping.duplicates, CallSite: "Mapper.Prepare"}
+		return PreparedMapping{err: err}, err
+	}
 	//
 	rv := PreparedMapping{
 		top:   typ,
 		value: value,
 		// Set err to ErrNoPlan; previously it was set to a new instance of pkgerr{Err:ErrNoPlay,Hint:"a hint"+typ.String()}
 		// but this creates many allocations and hurts performance.
-		err:   ErrNoPlan,
-		paths: mapping.ReflectPaths,
+		err:         ErrNoPlan,
+		paths:       mapping.ReflectPaths,
+		resolver:    mapping.resolver,
+		converters:  me.Converters,
+		sqlAdapters: me.SQLAdapters,
+	}
+	return rv, nil
+}
+
+// PrepareReadOnly creates a PreparedMapping bound to I for reading with ReadFields.
+//
+// Unlike Prepare, I can be a struct that is not addressable or a pointer or pointer chain to a
+// struct that is nil.  The returned PreparedMapping never allocates or modifies the bound value;
+// Rebind accepts values of the same type as I and all methods except Plan, ReadFields, Rebind,
+// and Copy return an error wrapping ErrReadOnly.
+func (me *Mapper) PrepareReadOnly(I interface{}) (PreparedMapping, error) {
+	var value reflect.Value
+	switch sw := I.(type) {
+	case reflect.Value:
+		value = sw
+	default:
+		value = reflect.ValueOf(I)
+	}
+	mapping := me.Map(I)
+	if me.StrictKeys && mapping.duplicates != nil {
+		err := pkgerr{Err: mapping.duplicates, CallSite: "Mapper.PrepareReadOnly"}
+		return PreparedMapping{err: err}, err
+	}
+	//
+	rv := PreparedMapping{
+		top:         value.Type(),
+		value:       readOnlyValue(value),
+		err:         pkgerr{Err: ErrReadOnly, CallSite: "Mapper.PrepareReadOnly", Hint: "use PreparedMapping.ReadFields"},
+		readOnly:    true,
+		paths:       mapping.ReflectPaths,
+		resolver:    mapping.resolver,
+		converters:  me.Converters,
+		sqlAdapters: me.SQLAdapters,
+	}
+	return rv, nil
+}
+
+// Unmap is the inverse of BoundMapping.Set; it copies the fields of the struct I into a
+// flat map whose keys are generated with the same rules as Map.
+//
+// Fields that are slices of structs are copied as []map[string]interface{} where each map
+// is the flat map of the element; MaxDepth does not apply to slice elements but elements
+// that refer back to a struct already being copied are nil.  Arrays are copied element by
+// element with the same indexed keys generated by Map; when IndexSlices is enabled slices of
+// structs are copied the same way.  Other slices and maps are not copied and nil pointers to
+// structs, arrays, or slices are omitted.
+//
+// I must be a struct or pointer to struct.
+func (me *Mapper) Unmap(I interface{}) (map[string]interface{}, error) {
+	v, err := unmapValue(I, "Mapper.Unmap")
+	if err != nil {
+		return nil, err
+	}
+	rv := map[string]interface{}{}
+	me.unmap(v, false, "", rv, newUnmapState(me.MaxDepth))
+	return rv, nil
+}
+
+// UnmapNested is the same as Unmap except nested structs are copied as nested
+// map[string]interface{} instead of being flattened with Join; the result is suitable
+// for MapGetter and Value.Fill.  Embedded structs listed in Elevated are merged into
+// the map of their parent.  Arrays of structs are copied as []map[string]interface{} and
+// other arrays are copied as they are.
+func (me *Mapper) UnmapNested(I interface{}) (map[string]interface{}, error) {
+	v, err := unmapValue(I, "Mapper.UnmapNested")
+	if err != nil {
+		return nil, err
 	}
+	rv := map[string]interface{}{}
+	me.unmap(v, true, "", rv, newUnmapState(me.MaxDepth))
 	return rv, nil
 }
 
+// mapperField is a struct field mapped by a Mapper.
+type mapperField struct {
+	field    reflect.StructField
+	typeInfo TypeInfo
+
+	// name is the generated name before it is joined with any prefix and tagged is true if
+	// name came from a struct tag.
+	name   string
+	tagged bool
+
+	// index is relative to the struct containing the field and has more than one element for
+	// fields promoted from embedded structs; path is the field names in index joined by "." as
+	// used by path.Tree.
+	index []int
+	path  string
+}
+
+// fields returns the fields of the struct described by typeInfo that are mapped by the Mapper.
+func (me *Mapper) fields(typeInfo TypeInfo) []mapperField {
+	if me.PromoteEmbedded {
+		return me.promotedFields(typeInfo)
+	}
+	rv := make([]mapperField, 0, len(typeInfo.StructFields))
+	for k, field := range typeInfo.StructFields {
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldTypeInfo := TypeCache.StatType(field.Type)
+		if me.Ignored.Has(fieldTypeInfo.Type) {
+			continue
+		}
+		name, tag := me.fieldName(field, fieldTypeInfo.Type)
+		if tag.skip || (me.TaggedFieldsOnly && !tag.found) {
+			// When the tag is "-" or tagged fields are required but the field is not tagged.
+			continue
+		}
+		rv = append(rv, mapperField{
+			field:    field,
+			typeInfo: fieldTypeInfo,
+			name:     name,
+			tagged:   tag.name != "",
+			index:    []int{k},
+			path:     field.Name,
+		})
+	}
+	return rv
+}
+
+// promotedFields returns the fields of the struct described by typeInfo after applying Go's
+// rules for promoting the fields of embedded structs; see Mapper.PromoteEmbedded.
+//
+// The logic mirrors encoding/json: embedded structs are expanded breadth first and when
+// several fields have the same name the shallowest field wins.  If more than one field is
+// at that depth then the field with a struct tag wins; otherwise none of them are mapped.
+func (me *Mapper) promotedFields(typeInfo TypeInfo) []mapperField {
+	type embedded struct {
+		T     reflect.Type
+		index []int
+		path  string
+	}
+	var candidates []mapperField
+	visited := map[reflect.Type]bool{}
+	for current := []embedded{{T: typeInfo.Type}}; len(current) > 0; {
+		var next []embedded
+		for _, e := range current {
+			if visited[e.T] {
+				continue
+			}
+			for k, field := range TypeCache.StatType(e.T).StructFields {
+				fieldTypeInfo := TypeCache.StatType(field.Type)
+				if me.Ignored.Has(fieldTypeInfo.Type) {
+					continue
+				} else if field.Anonymous && field.PkgPath != "" && field.Type.Kind() == reflect.Ptr {
+					// Embedded pointers to unexported types can not be instantiated.
+					continue
+				}
+				name, tag := me.fieldName(field, fieldTypeInfo.Type)
+				if tag.skip {
+					continue
+				}
+				tagged := tag.name != ""
+				index := append(append([]int(nil), e.index...), k)
+				fieldPath := field.Name
+				if e.path != "" {
+					fieldPath = e.path + "." + field.Name
+				}
+				if fieldTypeInfo.IsStruct && !me.isScalar(fieldTypeInfo) && (name == "" || (field.Anonymous && !tagged)) {
+					next = append(next, embedded{T: fieldTypeInfo.Type, index: index, path: fieldPath})
+					continue
+				} else if field.PkgPath != "" || (me.TaggedFieldsOnly && !tag.found) {
+					continue
+				}
+				candidates = append(candidates, mapperField{
+					field:    field,
+					typeInfo: fieldTypeInfo,
+					name:     name,
+					tagged:   tagged,
+					index:    index,
+					path:     fieldPath,
+				})
+			}
+		}
+		// NB  Types are marked visited after the whole depth is expanded so a type embedded
+		//     more than once at the same depth has its fields annihilate each other.
+		for _, e := range current {
+			visited[e.T] = true
+		}
+		current = next
+	}
+	//
+	// Keep the dominant field for each name.
+	byName := map[string][]int{}
+	for n, candidate := range candidates {
+		byName[candidate.name] = append(byName[candidate.name], n)
+	}
+	rv := make([]mapperField, 0, len(candidates))
+	for n, candidate := range candidates {
+		if dominantField(candidates, byName[candidate.name]) == n {
+			rv = append(rv, candidate)
+		}
+	}
+	// Restore the order in which the fields are declared.
+	sort.Slice(rv, func(i, j int) bool {
+		a, b := rv[i].index, rv[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return rv
+}
+
+// dominantField returns the position of the field in candidates that wins among the fields at
+// positions; it returns -1 if there is no winner.
+func dominantField(candidates []mapperField, positions []int) int {
+	depth := -1
+	for _, n := range positions {
+		if d := len(candidates[n].index); depth == -1 || d < depth {
+			depth = d
+		}
+	}
+	found, foundTagged, count := -1, -1, 0
+	for _, n := range positions {
+		if len(candidates[n].index) != depth {
+			continue
+		}
+		count++
+		found = n
+		if candidates[n].tagged {
+			if foundTagged != -1 {
+				return -1
+			}
+			foundTagged = n
+		}
+	}
+	if foundTagged != -1 {
+		return foundTagged
+	} else if count > 1 {
+		return -1
+	}
+	return found
+}
+
+// fieldName returns the name generated for field with type T before it is joined with any
+// prefix along with the parsed struct tag.  The name is empty when T is in Elevated.
+func (me *Mapper) fieldName(field reflect.StructField, T reflect.Type) (name string, tag structTag) {
+	if me.Elevated.Has(T) {
+		return "", tag
+	}
+	if tag = parseStructTag(field, me.Tags); tag.name != "" {
+		return tag.name, tag
+	}
+	name = field.Name
+	if me.Transform != nil {
+		name = me.Transform(name)
+	}
+	return name, tag
+}
+
+// unmapState tracks the struct types and pointers currently being traversed by Mapper.unmap
+// so recursive types and cyclic values terminate.
+type unmapState struct {
+	// maxDepth is the Mapper.MaxDepth or less than zero for no limit.
+	maxDepth int
+	active   map[reflect.Type]int
+	pointers map[unmapPointer]struct{}
+}
+
+// unmapPointer identifies a struct by address; the type is required because a struct and
+// its first field share the same address.
+type unmapPointer struct {
+	T reflect.Type
+	P uintptr
+}
+
+// newUnmapState returns a new unmapState.
+func newUnmapState(maxDepth int) *unmapState {
+	return &unmapState{
+		maxDepth: maxDepth,
+		active:   map[reflect.Type]int{},
+		pointers: map[unmapPointer]struct{}{},
+	}
+}
+
+// unmapValue returns the struct at the end of the pointer chain in I.
+func unmapValue(I interface{}, callsite string) (reflect.Value, error) {
+	var v reflect.Value
+	switch sw := I.(type) {
+	case reflect.Value:
+		v = sw
+	default:
+		v = reflect.ValueOf(I)
+	}
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return v, pkgerr{Err: ErrUnsupported, CallSite: callsite, Context: fmt.Sprintf("expected struct but got %T", I)}
+	}
+	return v, nil
+}
+
+// unmap copies the fields of the struct v into dst.  When nested is false keys are joined
+// with prefix; otherwise nested structs are copied into nested maps.
+func (me *Mapper) unmap(v reflect.Value, nested bool, prefix string, dst map[string]interface{}, state *unmapState) {
+	typeInfo := TypeCache.StatType(v.Type())
+	state.active[typeInfo.Type]++
+	defer func() { state.active[typeInfo.Type]-- }()
+	if v.CanAddr() {
+		ptr := unmapPointer{T: typeInfo.Type, P: v.UnsafeAddr()}
+		state.pointers[ptr] = struct{}{}
+		defer delete(state.pointers, ptr)
+	}
+	//
+	for _, f := range me.fields(typeInfo) {
+		fieldTypeInfo, name := f.typeInfo, f.name
+		fv, ok := unmapField(v, f.index)
+		if !ok {
+			// Fields promoted through nil embedded pointers are omitted.
+			continue
+		}
+		key := name
+		if !nested && prefix != "" && name != "" {
+			key = prefix + me.Join + name
+		} else if !nested && prefix != "" {
+			key = prefix
+		}
+		//
+		if me.isScalar(fieldTypeInfo) && !fieldTypeInfo.IsScalar {
+			// Types in TreatAsScalar are copied as they are.
+			dst[key] = fv.Interface()
+			continue
+		}
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		var elemTypeInfo TypeInfo
+		if fieldTypeInfo.IsSlice || fieldTypeInfo.IsArray {
+			elemTypeInfo = TypeCache.StatType(fieldTypeInfo.ElemType)
+		}
+		elemIsStruct := elemTypeInfo.IsStruct && !me.isScalar(elemTypeInfo)
+		switch {
+		case fieldTypeInfo.IsStruct:
+			if !state.enter(fv) {
+				continue
+			} else if nested && name != "" {
+				m := map[string]interface{}{}
+				me.unmap(fv, true, "", m, state)
+				dst[key] = m
+			} else {
+				me.unmap(fv, nested, key, dst, state)
+			}
+
+		case !nested && ((fieldTypeInfo.IsArray && !isByteArray(fieldTypeInfo) && (elemIsStruct || me.isScalar(elemTypeInfo))) || (fieldTypeInfo.IsSlice && elemIsStruct && me.IndexSlices && key != "")):
+			// Flat maps contain the array elements, or slice elements if IndexSlices is enabled, with
+			// the same keys generated by Map; nil pointers to arrays or slices are omitted.
+			if fv.Kind() == reflect.Ptr {
+				continue
+			}
+			for n, size := 0, fv.Len(); n < size; n++ {
+				elemKey := strconv.Itoa(n)
+				if key != "" {
+					elemKey = key + me.Join + elemKey
+				}
+				elem := fv.Index(n)
+				for elem.Kind() == reflect.Ptr && !elem.IsNil() {
+					elem = elem.Elem()
+				}
+				if !elemIsStruct {
+					dst[elemKey] = nil
+					if elem.Kind() != reflect.Ptr {
+						dst[elemKey] = elem.Interface()
+					}
+				} else if elem.Kind() == reflect.Struct && !state.cyclic(elem) {
+					me.unmap(elem, false, elemKey, dst, state)
+				}
+			}
+
+		case (fieldTypeInfo.IsSlice || fieldTypeInfo.IsArray) && elemIsStruct:
+			if fv.Kind() == reflect.Ptr || (fv.Kind() == reflect.Slice && fv.IsNil()) {
+				dst[key] = []map[string]interface{}(nil)
+				continue
+			}
+			slice := make([]map[string]interface{}, fv.Len())
+			for n := range slice {
+				elem := fv.Index(n)
+				for elem.Kind() == reflect.Ptr && !elem.IsNil() {
+					elem = elem.Elem()
+				}
+				if elem.Kind() != reflect.Struct || state.cyclic(elem) {
+					continue
+				}
+				slice[n] = map[string]interface{}{}
+				me.unmap(elem, nested, "", slice[n], state)
+			}
+			dst[key] = slice
+
+		case fieldTypeInfo.IsScalar, fieldTypeInfo.IsSlice, fieldTypeInfo.IsArray, fieldTypeInfo.IsMap, fieldTypeInfo.Kind == reflect.Interface:
+			if fv.Kind() == reflect.Ptr {
+				dst[key] = nil
+			} else {
+				dst[key] = fv.Interface()
+			}
+		}
+	}
+}
+
+// unmapField returns the field of v described by index; ok is false if a nil pointer to an
+// embedded struct is encountered.
+func unmapField(v reflect.Value, index []int) (rv reflect.Value, ok bool) {
+	for k, n := range index {
+		if k > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return v, false
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(n)
+	}
+	return v, true
+}
+
+// enter returns true if unmap should traverse into the struct field v; it returns false if v is a
+// nil pointer, v is already being traversed, or the type has been expanded to the maximum depth.
+func (state *unmapState) enter(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	} else if state.maxDepth >= 0 && state.active[v.Type()] > state.maxDepth {
+		return false
+	}
+	return !state.cyclic(v)
+}
+
+// cyclic returns true if the struct v is already being traversed.
+func (state *unmapState) cyclic(v reflect.Value) bool {
+	if !v.CanAddr() {
+		return false
+	}
+	_, ok := state.pointers[unmapPointer{T: v.Type(), P: v.UnsafeAddr()}]
+	return ok
+}
+
 // Copy creates a copy of the Mapping.
 func (me Mapping) Copy() Mapping {
 	rv := Mapping{
@@ -323,11 +1088,24 @@ func (me Mapping) Copy() Mapping {
 		Indeces:      map[string][]int{},
 		StructFields: map[string]reflect.StructField{},
 		ReflectPaths: map[string]path.ReflectPath{},
+		Options:      map[string]TagOptions{},
+		Defaults:     map[string]string{},
+		HasPointers:  me.HasPointers,
+		indexed:      me.indexed,
+		resolver:     me.resolver,
+		duplicates:   me.duplicates,
+		defaultKeys:  append([]string(nil), me.defaultKeys...),
 	}
 	for _, key := range me.Keys {
 		rv.Indeces[key] = append([]int(nil), me.Indeces[key]...)
 		rv.StructFields[key] = me.StructFields[key]
 		rv.ReflectPaths[key] = me.ReflectPaths[key]
+		if options, ok := me.Options[key]; ok {
+			rv.Options[key] = append(TagOptions(nil), options...)
+		}
+		if value, ok := me.Defaults[key]; ok {
+			rv.Defaults[key] = value
+		}
 	}
 	return rv
 }
@@ -346,6 +1124,18 @@ func (me Mapping) Lookup(key string) (indeces []int, ok bool) {
 	return indeces, ok
 }
 
+// Match returns the pattern in Keys that matches key when key contains slice indexes; the
+// indexes are returned in order.  If key does not contain slice indexes or there is no such
+// pattern then ok is false.  See Mapper.IndexSlices.
+func (me Mapping) Match(key string) (pattern string, indexes []int, ok bool) {
+	if pattern, indexes, ok = me.indexed.match(key); !ok {
+		return "", nil, false
+	} else if _, ok = me.Indeces[pattern]; !ok {
+		return "", nil, false
+	}
+	return pattern, indexes, true
+}
+
 // String returns the Mapping as a string value.
 func (me Mapping) String() string {
 	parts := []string{}
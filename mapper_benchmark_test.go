@@ -228,6 +228,103 @@ func BenchmarkMapper(b *testing.B) {
 			}
 		}
 	})
+	//
+	b.Run("Bind Rebind unsafe", func(b *testing.B) {
+		mapper := &set.Mapper{
+			Elevated: set.NewTypeList(Common{}, Timestamps{}),
+			Join:     "_",
+			//
+			UnsafeOffsets: true,
+		}
+		//
+		var row MapperBenchmarkJsonRow
+		var k int
+		dest := make([]T, 100)
+		bound, err := mapper.Bind(&dest[0])
+		if err != nil {
+			b.Fatalf("Unable to bind: %v", err.Error())
+		}
+		for n := 0; n < b.N; n++ {
+			k = n % size
+			row = rowsDecoded[k]
+			bound.Rebind(&dest[k])
+			//
+			_ = bound.Set("Id", row.Id)
+			_ = bound.Set("CreatedTime", row.CreatedTime)
+			_ = bound.Set("ModifiedTime", row.ModifiedTime)
+			_ = bound.Set("Price", row.Price)
+			_ = bound.Set("Quantity", row.Quantity)
+			_ = bound.Set("Total", row.Total)
+			//
+			_ = bound.Set("Customer_Id", row.CustomerId)
+			_ = bound.Set("Customer_First", row.CustomerFirst)
+			_ = bound.Set("Customer_Last", row.CustomerLast)
+			//
+			_ = bound.Set("Vendor_Id", row.VendorId)
+			_ = bound.Set("Vendor_Name", row.VendorName)
+			_ = bound.Set("Vendor_Description", row.VendorDescription)
+			_ = bound.Set("Vendor_Contact_Id", row.VendorContactId)
+			_ = bound.Set("Vendor_Contact_First", row.VendorContactFirst)
+			_ = bound.Set("Vendor_Contact_Last", row.VendorContactLast)
+			//
+			if err := bound.Err(); err != nil {
+				b.Fatalf("Unable to set: %v", err.Error())
+			}
+		}
+	})
+	//
+	b.Run("Prepare Rebind unsafe", func(b *testing.B) {
+		mapper := &set.Mapper{
+			Elevated: set.NewTypeList(Common{}, Timestamps{}),
+			Join:     "_",
+			//
+			UnsafeOffsets: true,
+		}
+		//
+		var row MapperBenchmarkJsonRow
+		var k int
+		dest := make([]T, 100)
+		prepared, err := mapper.Prepare(&dest[0])
+		if err != nil {
+			b.Fatalf("error preparing %v", err.Error())
+		}
+		err = prepared.Plan(
+			"Id", "CreatedTime", "ModifiedTime",
+			"Price", "Quantity", "Total",
+			"Customer_Id", "Customer_First", "Customer_Last",
+			"Vendor_Id", "Vendor_Name", "Vendor_Description", "Vendor_Contact_Id", "Vendor_Contact_First", "Vendor_Contact_Last")
+		if err != nil {
+			b.Fatalf("error preparing plan %v", err.Error())
+		}
+		for n := 0; n < b.N; n++ {
+			k = n % size
+			row = rowsDecoded[k]
+			prepared.Rebind(&dest[k])
+			//
+			//
+			_ = prepared.Set(row.Id)
+			_ = prepared.Set(row.CreatedTime)
+			_ = prepared.Set(row.ModifiedTime)
+			_ = prepared.Set(row.Price)
+			_ = prepared.Set(row.Quantity)
+			_ = prepared.Set(row.Total)
+			//
+			_ = prepared.Set(row.CustomerId)
+			_ = prepared.Set(row.CustomerFirst)
+			_ = prepared.Set(row.CustomerLast)
+			//
+			_ = prepared.Set(row.VendorId)
+			_ = prepared.Set(row.VendorName)
+			_ = prepared.Set(row.VendorDescription)
+			_ = prepared.Set(row.VendorContactId)
+			_ = prepared.Set(row.VendorContactFirst)
+			_ = prepared.Set(row.VendorContactLast)
+			//
+			if err := prepared.Err(); err != nil {
+				b.Fatalf("Unable to set: %v", err.Error())
+			}
+		}
+	})
 }
 func BenchmarkMapperBaseline(b *testing.B) {
 	rows, size := loadBenchmarkMapperData(b)
@@ -279,3 +376,98 @@ func BenchmarkMapperBaseline(b *testing.B) {
 		dest.Vendor.Contact.Last = row.VendorContactLast
 	}
 }
+
+func BenchmarkMapperUnsafeOffsets(b *testing.B) {
+	type Contact struct {
+		Id    int
+		First string
+		Last  string
+	}
+	type T struct {
+		Id       int
+		Price    float64
+		Active   bool
+		Customer *Contact
+		Vendor   struct {
+			Name    string
+			Contact **Contact
+		}
+	}
+	fields := []string{
+		"Id", "Price", "Active",
+		"Customer_Id", "Customer_First", "Customer_Last",
+		"Vendor_Name", "Vendor_Contact_Id", "Vendor_Contact_First", "Vendor_Contact_Last",
+	}
+	// NB  Values are boxed once so the benchmark measures access rather than allocation.
+	values := []interface{}{
+		42, 9.99, true,
+		1, "Bob", "Smith",
+		"Acme", 2, "Alice", "Jones",
+	}
+	//
+	b.ResetTimer()
+	//
+	for _, unsafe := range []bool{false, true} {
+		mapper := &set.Mapper{
+			Join:          "_",
+			UnsafeOffsets: unsafe,
+		}
+		name := "reflect"
+		if unsafe {
+			name = "unsafe"
+		}
+		b.Run("Bind Set "+name, func(b *testing.B) {
+			var dest T
+			bound, err := mapper.Bind(&dest)
+			if err != nil {
+				b.Fatalf("Unable to bind: %v", err.Error())
+			}
+			for n := 0; n < b.N; n++ {
+				for k, field := range fields {
+					_ = bound.Set(field, values[k])
+				}
+			}
+			if err := bound.Err(); err != nil {
+				b.Fatalf("Unable to set: %v", err.Error())
+			}
+		})
+		b.Run("Prepare Set "+name, func(b *testing.B) {
+			var dest T
+			prepared, err := mapper.Prepare(&dest)
+			if err != nil {
+				b.Fatalf("error preparing %v", err.Error())
+			}
+			if err = prepared.Plan(fields...); err != nil {
+				b.Fatalf("error preparing plan %v", err.Error())
+			}
+			for n := 0; n < b.N; n++ {
+				prepared.Rebind(&dest)
+				for _, value := range values {
+					_ = prepared.Set(value)
+				}
+			}
+			if err := prepared.Err(); err != nil {
+				b.Fatalf("Unable to set: %v", err.Error())
+			}
+		})
+		b.Run("Prepare ReadFields "+name, func(b *testing.B) {
+			var dest T
+			prepared, err := mapper.Prepare(&dest)
+			if err != nil {
+				b.Fatalf("error preparing %v", err.Error())
+			}
+			if err = prepared.Plan(fields...); err != nil {
+				b.Fatalf("error preparing plan %v", err.Error())
+			}
+			for _, value := range values {
+				_ = prepared.Set(value)
+			}
+			rv := make([]interface{}, len(fields))
+			for n := 0; n < b.N; n++ {
+				if rv, err = prepared.ReadFields(rv); err != nil {
+					b.Fatalf("Unable to read: %v", err.Error())
+				}
+			}
+		})
+	}
+}
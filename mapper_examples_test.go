@@ -411,3 +411,77 @@ func ExampleMapper_Prepare_reflectValue() {
 	// t 24 -3.14
 	// u 100 Works!
 }
+
+func ExampleMapper_Unmap() {
+	// Unmap and UnmapNested copy a struct into a map using the same names as Map.
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Person struct {
+		Name    string    `json:"name"`
+		Address Address   `json:"address"`
+		Others  []Address `json:"others"`
+	}
+	p := Person{
+		Name:    "Bob",
+		Address: Address{City: "Springfield"},
+		Others:  []Address{{City: "Shelbyville"}},
+	}
+	mapper := &set.Mapper{
+		Tags: []string{"json"},
+		Join: ".",
+	}
+	flat, _ := mapper.Unmap(p)
+	fmt.Println(flat)
+	nested, _ := mapper.UnmapNested(p)
+	fmt.Println(nested)
+
+	// Output: map[address.city:Springfield name:Bob others:[map[city:Shelbyville]]]
+	// map[address:map[city:Springfield] name:Bob others:[map[city:Shelbyville]]]
+}
+
+func ExampleMapper_indexSlices() {
+	// With IndexSlices slices of structs are mapped with an index placeholder.
+	type Server struct {
+		Host string
+		Port int
+	}
+	type Config struct {
+		Servers []Server
+	}
+	mapper := &set.Mapper{
+		Join:        "_",
+		Transform:   strings.ToUpper,
+		IndexSlices: true,
+	}
+	fmt.Println(mapper.Map(Config{}).Keys)
+
+	var c Config
+	b, _ := mapper.Bind(&c)
+	_ = b.Set("SERVERS_1_HOST", "db")
+	_ = b.Set("SERVERS_1_PORT", "5432")
+	_ = b.Set("SERVERS_0_HOST", "web")
+	fmt.Println(c.Servers)
+
+	// Output: [SERVERS_#_HOST SERVERS_#_PORT]
+	// [{web 0} {db 5432}]
+}
+
+func ExampleMapper_normalize() {
+	// With Normalize keys that differ by case or separators still match.
+	type User struct {
+		UserID   int
+		UserName string
+	}
+	mapper := &set.Mapper{
+		Join:      "_",
+		Normalize: set.NormalizeKey,
+	}
+	var u User
+	b, _ := mapper.Bind(&u)
+	_ = b.Set("User ID", "42")
+	_ = b.Set("user_name", "Bob")
+	fmt.Println(u.UserID, u.UserName)
+
+	// Output: 42 Bob
+}
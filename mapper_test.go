@@ -1,6 +1,8 @@
 package set_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -189,6 +191,249 @@ func TestMapper_Map_TaggedFieldsOnly(t *testing.T) {
 	}
 }
 
+func TestMapper_MapErr(t *testing.T) {
+	type Db struct {
+		Id int
+	}
+	type A struct {
+		Db
+		Name string
+	}
+	type B struct {
+		Db
+		Name string `json:"name"`
+	}
+	type T struct {
+		A
+		B
+		Title string
+		TITLE string
+		Items [2]struct {
+			X int
+			Y int `json:"x"`
+		}
+	}
+	mapper := &set.Mapper{
+		Elevated:  set.NewTypeList(A{}, B{}, Db{}),
+		Tags:      []string{"json"},
+		Transform: strings.ToLower,
+		Join:      "_",
+	}
+	t.Run("map", func(t *testing.T) {
+		chk := assert.New(t)
+		mapping, err := mapper.MapErr(T{})
+		chk.ErrorIs(err, set.ErrDuplicateKey)
+		chk.Equal(mapper.Map(T{}).Keys, mapping.Keys)
+		var duplicates *set.DuplicateKeyError
+		chk.True(errors.As(err, &duplicates))
+		chk.Equal(reflect.TypeOf(T{}), duplicates.Type)
+		chk.Equal([]set.DuplicateKey{
+			{Key: "id", Fields: []string{"A.Db.Id", "B.Db.Id"}},
+			{Key: "name", Fields: []string{"A.Name", "B.Name"}},
+			{Key: "title", Fields: []string{"Title", "TITLE"}},
+			{Key: "items_0_x", Fields: []string{"Items[0].X", "Items[0].Y"}},
+			{Key: "items_1_x", Fields: []string{"Items[1].X", "Items[1].Y"}},
+		}, duplicates.Duplicates)
+		chk.True(duplicates.Has("title"))
+		chk.False(duplicates.Has("items"))
+		chk.Contains(err.Error(), "key [title] from fields [Title, TITLE]")
+		//
+		mapping, err = mapper.MapErr(Db{})
+		chk.NoError(err)
+		chk.Equal([]string{"id"}, mapping.Keys)
+	})
+	t.Run("strict keys", func(t *testing.T) {
+		chk := assert.New(t)
+		var v T
+		_, err := mapper.Bind(&v)
+		chk.NoError(err)
+		_, err = mapper.Prepare(&v)
+		chk.NoError(err)
+		//
+		strict := &set.Mapper{
+			Elevated:   mapper.Elevated,
+			Tags:       mapper.Tags,
+			Transform:  mapper.Transform,
+			Join:       "_",
+			StrictKeys: true,
+		}
+		b, err := strict.Bind(&v)
+		chk.ErrorIs(err, set.ErrDuplicateKey)
+		chk.ErrorIs(b.Err(), set.ErrDuplicateKey)
+		var duplicates *set.DuplicateKeyError
+		chk.True(errors.As(err, &duplicates))
+		chk.Len(duplicates.Duplicates, 5)
+		_, err = strict.Prepare(&v)
+		chk.ErrorIs(err, set.ErrDuplicateKey)
+		chk.True(errors.As(err, &duplicates))
+		//
+		var db Db
+		_, err = strict.Bind(&db)
+		chk.NoError(err)
+		_, err = strict.Prepare(&db)
+		chk.NoError(err)
+	})
+}
+
+func TestMapper_Normalize(t *testing.T) {
+	type Address struct {
+		ZipCode string
+	}
+	type User struct {
+		UserID   int
+		UserName string
+		User_ID  string
+		Address  Address
+		Items    []Address
+	}
+	mapper := &set.Mapper{
+		Join:        "_",
+		Normalize:   set.NormalizeKey,
+		IndexSlices: true,
+	}
+	t.Run("normalize key", func(t *testing.T) {
+		chk := assert.New(t)
+		for _, key := range []string{"User ID", "user_id", "USERID", "user-id", "userId"} {
+			chk.Equal("userid", set.NormalizeKey(key), key)
+		}
+		chk.Equal("straße1", set.NormalizeKey("Straße #1"))
+	})
+	t.Run("bind", func(t *testing.T) {
+		chk := assert.New(t)
+		var u User
+		b, err := mapper.Bind(&u)
+		chk.NoError(err)
+		chk.NoError(b.Set("User Name", "bob"))
+		chk.NoError(b.Set("ADDRESS ZIP-CODE", "12345"))
+		chk.NoError(b.Set("Items_1_ZipCode", "67890"))
+		chk.Equal("bob", u.UserName)
+		chk.Equal("12345", u.Address.ZipCode)
+		chk.Equal("67890", u.Items[1].ZipCode)
+		//
+		// Exact matches are not ambiguous.
+		chk.NoError(b.Set("UserID", 42))
+		chk.NoError(b.Set("User_ID", "str"))
+		chk.Equal(42, u.UserID)
+		chk.Equal("str", u.User_ID)
+		//
+		err = b.Set("user id", 1)
+		chk.ErrorIs(err, set.ErrAmbiguousField)
+		chk.Contains(err.Error(), "matches [UserID, User_ID]")
+		chk.ErrorIs(b.Err(), set.ErrAmbiguousField)
+		_, err = b.Field("USERID")
+		chk.ErrorIs(err, set.ErrAmbiguousField)
+		_, err = b.Fields([]string{"username", "userid"}, nil)
+		chk.ErrorIs(err, set.ErrAmbiguousField)
+		_, err = b.Assignables([]string{"userid"}, nil)
+		chk.ErrorIs(err, set.ErrAmbiguousField)
+		c := b.Copy()
+		chk.ErrorIs(c.Set("unknown", 1), set.ErrUnknownField)
+		//
+		values, err := b.Fields([]string{"username", "address_zipcode"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"bob", "12345"}, values)
+	})
+	t.Run("prepare", func(t *testing.T) {
+		chk := assert.New(t)
+		var u User
+		p, err := mapper.Prepare(&u)
+		chk.NoError(err)
+		chk.NoError(p.Plan("user name", "Address.ZipCode"))
+		chk.NoError(p.Set("alice"))
+		chk.NoError(p.Set("54321"))
+		chk.Equal("alice", u.UserName)
+		chk.Equal("54321", u.Address.ZipCode)
+		//
+		err = p.Plan("username", "user-id")
+		chk.ErrorIs(err, set.ErrAmbiguousField)
+		chk.ErrorIs(p.Err(), set.ErrNoPlan)
+		c := p.Copy()
+		chk.ErrorIs(c.Plan("missing"), set.ErrUnknownField)
+	})
+	t.Run("slice patterns", func(t *testing.T) {
+		chk := assert.New(t)
+		type Server struct {
+			Host string
+		}
+		type Config struct {
+			Servers []Server
+		}
+		var c Config
+		b, err := mapper.Bind(&c)
+		chk.NoError(err)
+		chk.ErrorIs(b.Set("SERVERS_HOST", "x"), set.ErrUnknownField)
+		chk.Nil(c.Servers)
+		chk.NoError(b.Set("Servers_0_Host", "x"))
+		chk.Equal([]Server{{Host: "x"}}, c.Servers)
+	})
+	t.Run("without normalize", func(t *testing.T) {
+		chk := assert.New(t)
+		var u User
+		b, err := set.DefaultMapper.Bind(&u)
+		chk.NoError(err)
+		chk.ErrorIs(b.Set("username", "bob"), set.ErrUnknownField)
+	})
+}
+
+func TestMapper_Map_TagOptions(t *testing.T) {
+	type Address struct {
+		City string `json:"city,required"`
+	}
+	type Person struct {
+		Name    string  `json:"name,omitempty,alias=full_name|fullname,default=anonymous,required"`
+		Age     int     `json:",omitempty"`
+		Secret  string  `json:"-"`
+		Dash    string  `json:"-,"`
+		Email   string  `db:"email_address" json:"email"`
+		Address Address `json:"addr,omitempty"`
+	}
+	chk := assert.New(t)
+	mapper := &set.Mapper{
+		Tags: []string{"json"},
+		Join: "_",
+	}
+	mapping := mapper.Map(Person{})
+	chk.Equal([]string{"name", "Age", "-", "email", "addr_city"}, mapping.Keys)
+	chk.Equal(set.TagOptions{"omitempty", "alias=full_name|fullname", "default=anonymous", "required"}, mapping.Options["name"])
+	chk.Equal(set.TagOptions{"omitempty"}, mapping.Options["Age"])
+	chk.Equal(set.TagOptions{"required"}, mapping.Options["addr_city"])
+	chk.NotContains(mapping.Options, "-")
+	chk.NotContains(mapping.Options, "email")
+	chk.Equal(mapping.Options, mapping.Copy().Options)
+	//
+	options := mapping.Options["name"]
+	chk.True(options.Has("required"))
+	chk.True(options.Has("default"))
+	chk.False(options.Has("alias=full_name"))
+	chk.False(options.Has("req"))
+	value, ok := options.Lookup("default")
+	chk.True(ok)
+	chk.Equal("anonymous", value)
+	value, ok = options.Lookup("omitempty")
+	chk.True(ok)
+	chk.Equal("", value)
+	_, ok = options.Lookup("missing")
+	chk.False(ok)
+	chk.Equal([]string{"full_name", "fullname"}, options.Values("alias"))
+	chk.Nil(options.Values("required"))
+	chk.Nil(options.Values("missing"))
+	//
+	// Only the first tag found is used.
+	mapper = &set.Mapper{
+		Tags: []string{"db", "json"},
+		Join: "_",
+	}
+	chk.Equal([]string{"name", "Age", "-", "email_address", "addr_city"}, mapper.Map(Person{}).Keys)
+	//
+	var p Person
+	b, err := mapper.Bind(&p)
+	chk.NoError(err)
+	chk.NoError(b.Set("name", "Bob"))
+	chk.ErrorIs(b.Set("Secret", "x"), set.ErrUnknownField)
+	chk.ErrorIs(b.Set("name,omitempty", "x"), set.ErrUnknownField)
+	chk.Equal("Bob", p.Name)
+}
+
 func TestMapperBindCollision(t *testing.T) {
 	chk := assert.New(t)
 	type Db struct {
@@ -225,6 +470,567 @@ func TestMapperBindCollision(t *testing.T) {
 	}
 }
 
+func TestMapper_Map_MaxDepth(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	type Parent struct {
+		Name  string
+		Child *struct {
+			Name   string
+			Parent *Parent
+		}
+	}
+	t.Run("self referencing", func(t *testing.T) {
+		chk := assert.New(t)
+		expect := map[int][]string{
+			0: {"Name"},
+			1: {"Name", "Next_Name"},
+			2: {"Name", "Next_Name", "Next_Next_Name"},
+		}
+		for depth, keys := range expect {
+			mapper := &set.Mapper{
+				Join:     "_",
+				MaxDepth: depth,
+			}
+			mapping := mapper.Map(Node{})
+			chk.Equal(keys, mapping.Keys)
+		}
+	})
+	t.Run("mutually recursive", func(t *testing.T) {
+		chk := assert.New(t)
+		mapper := &set.Mapper{
+			Join: "_",
+		}
+		mapping := mapper.Map(Parent{})
+		chk.Equal([]string{"Name", "Child_Name"}, mapping.Keys)
+		//
+		mapper = &set.Mapper{
+			Join:     "_",
+			MaxDepth: 1,
+		}
+		mapping = mapper.Map(Parent{})
+		chk.Equal([]string{"Name", "Child_Name", "Child_Parent_Name", "Child_Parent_Child_Name"}, mapping.Keys)
+		chk.Equal([]int{1, 1, 1, 0}, mapping.Get("Child_Parent_Child_Name"))
+	})
+	t.Run("bind", func(t *testing.T) {
+		chk := assert.New(t)
+		mapper := &set.Mapper{
+			Join:     "_",
+			MaxDepth: 2,
+		}
+		var n Node
+		b, err := mapper.Bind(&n)
+		chk.NoError(err)
+		chk.NoError(b.Set("Name", "a"))
+		chk.NoError(b.Set("Next_Name", "b"))
+		chk.NoError(b.Set("Next_Next_Name", "c"))
+		chk.ErrorIs(b.Set("Next_Next_Next_Name", "d"), set.ErrUnknownField)
+		chk.Equal("a", n.Name)
+		chk.Equal("b", n.Next.Name)
+		chk.Equal("c", n.Next.Next.Name)
+		chk.Nil(n.Next.Next.Next)
+	})
+}
+
+func TestMapper_Map_Array(t *testing.T) {
+	type Point struct {
+		X, Y int
+	}
+	type Shape struct {
+		Name string
+		Vec  [3]int
+		Pts  [2]Point
+		PPts *[2]*Point
+		Hash [4]byte
+	}
+	mapper := &set.Mapper{
+		Join: "_",
+	}
+	t.Run("keys", func(t *testing.T) {
+		chk := assert.New(t)
+		mapping := mapper.Map(Shape{})
+		chk.Equal([]string{
+			"Name",
+			"Vec", "Vec_0", "Vec_1", "Vec_2",
+			"Pts", "Pts_0_X", "Pts_0_Y", "Pts_1_X", "Pts_1_Y",
+			"PPts", "PPts_0_X", "PPts_0_Y", "PPts_1_X", "PPts_1_Y",
+			"Hash",
+		}, mapping.Keys)
+		chk.Equal([]int{1}, mapping.Get("Vec_2"))
+		chk.Equal([]int{2}, mapping.Get("Pts_1_Y"))
+	})
+	t.Run("bind", func(t *testing.T) {
+		chk := assert.New(t)
+		var s Shape
+		b, err := mapper.Bind(&s)
+		chk.NoError(err)
+		chk.NoError(b.Set("Vec_1", "5"))
+		chk.NoError(b.Set("Pts_1_Y", 7))
+		chk.NoError(b.Set("PPts_0_X", 9))
+		chk.Equal([3]int{0, 5, 0}, s.Vec)
+		chk.Equal(7, s.Pts[1].Y)
+		chk.NotNil(s.PPts)
+		chk.Equal(9, s.PPts[0].X)
+		chk.Nil(s.PPts[1])
+		//
+		field, err := b.Field("Vec_1")
+		chk.NoError(err)
+		chk.Equal(5, field.WriteValue.Interface())
+		values, err := b.Fields([]string{"Vec_1", "Pts_1_Y"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{5, 7}, values)
+		//
+		chk.NoError(b.Set("Vec", []string{"1", "2"}))
+		chk.Equal([3]int{1, 2, 0}, s.Vec)
+		chk.NoError(b.Set("Hash", []byte{1, 2, 3, 4}))
+		chk.Equal([4]byte{1, 2, 3, 4}, s.Hash)
+		chk.ErrorIs(b.Set("Hash_0", 1), set.ErrUnknownField)
+	})
+	t.Run("prepare", func(t *testing.T) {
+		chk := assert.New(t)
+		var s Shape
+		p, err := mapper.Prepare(&s)
+		chk.NoError(err)
+		chk.NoError(p.Plan("Vec_0", "Pts_0_X", "PPts_1_Y"))
+		chk.NoError(p.Set(1))
+		chk.NoError(p.Set(2))
+		chk.NoError(p.Set(3))
+		chk.Equal(1, s.Vec[0])
+		chk.Equal(2, s.Pts[0].X)
+		chk.Equal(3, s.PPts[1].Y)
+		assignables, err := p.Assignables(nil)
+		chk.NoError(err)
+		chk.Equal(&s.Vec[0], assignables[0])
+	})
+	t.Run("unmap", func(t *testing.T) {
+		chk := assert.New(t)
+		s := Shape{Name: "tri", Vec: [3]int{1, 2, 3}, Pts: [2]Point{{1, 2}, {3, 4}}, Hash: [4]byte{5, 6, 7, 8}}
+		m, err := mapper.Unmap(s)
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{
+			"Name":  "tri",
+			"Vec_0": 1, "Vec_1": 2, "Vec_2": 3,
+			"Pts_0_X": 1, "Pts_0_Y": 2, "Pts_1_X": 3, "Pts_1_Y": 4,
+			"Hash": [4]byte{5, 6, 7, 8},
+		}, m)
+		//
+		m, err = mapper.UnmapNested(s)
+		chk.NoError(err)
+		chk.Equal([3]int{1, 2, 3}, m["Vec"])
+		chk.Equal([]map[string]interface{}{{"X": 1, "Y": 2}, {"X": 3, "Y": 4}}, m["Pts"])
+		chk.Equal([]map[string]interface{}(nil), m["PPts"])
+	})
+}
+
+func TestMapper_IndexSlices(t *testing.T) {
+	type Port struct {
+		Number int
+	}
+	type Server struct {
+		Host  string
+		Ports []*Port
+		Tags  [2]string
+	}
+	type Config struct {
+		Name    string
+		Servers []Server
+		Backup  *[]Server
+	}
+	mapper := &set.Mapper{
+		Join:          "_",
+		IndexSlices:   true,
+		MaxSliceIndex: 100,
+	}
+	t.Run("keys", func(t *testing.T) {
+		chk := assert.New(t)
+		mapping := mapper.Map(Config{})
+		chk.Equal([]string{
+			"Name",
+			"Servers_#_Host", "Servers_#_Ports_#_Number", "Servers_#_Tags", "Servers_#_Tags_0", "Servers_#_Tags_1",
+			"Backup_#_Host", "Backup_#_Ports_#_Number", "Backup_#_Tags", "Backup_#_Tags_0", "Backup_#_Tags_1",
+		}, mapping.Keys)
+		chk.Equal([]int{1}, mapping.Get("Servers_#_Ports_#_Number"))
+		chk.Equal("Number", mapping.StructFields["Servers_#_Ports_#_Number"].Name)
+		//
+		pattern, indexes, ok := mapping.Match("Servers_3_Ports_12_Number")
+		chk.True(ok)
+		chk.Equal("Servers_#_Ports_#_Number", pattern)
+		chk.Equal([]int{3, 12}, indexes)
+		pattern, indexes, ok = mapping.Match("Servers_3_Tags_1")
+		chk.True(ok)
+		chk.Equal("Servers_#_Tags_1", pattern)
+		chk.Equal([]int{3}, indexes)
+		for _, key := range []string{"Name", "Servers_x_Host", "Servers_1_Other", "Servers_101_Host", "Name_0", "Servers_01_Host", "Servers_00_Host"} {
+			_, _, ok = mapping.Match(key)
+			chk.False(ok, key)
+		}
+		//
+		// Recursive types obey MaxDepth.
+		type Node struct {
+			Name     string
+			Children []Node
+		}
+		chk.Equal([]string{"Name"}, mapper.Map(Node{}).Keys)
+		deep := &set.Mapper{Join: "_", IndexSlices: true, MaxDepth: 1}
+		chk.Equal([]string{"Name", "Children_#_Name"}, deep.Map(Node{}).Keys)
+		//
+		// Without IndexSlices slices of structs are not mapped.
+		chk.Equal([]string{"Name"}, set.DefaultMapper.Map(Config{}).Keys)
+	})
+	t.Run("bind", func(t *testing.T) {
+		chk := assert.New(t)
+		var c Config
+		b, err := mapper.Bind(&c)
+		chk.NoError(err)
+		chk.NoError(b.Set("Servers_1_Host", "b"))
+		chk.NoError(b.Set("Servers_0_Host", "a"))
+		chk.NoError(b.Set("Servers_1_Ports_2_Number", "443"))
+		chk.NoError(b.Set("Servers_0_Tags_1", "primary"))
+		chk.NoError(b.Set("Backup_0_Host", "c"))
+		chk.Len(c.Servers, 2)
+		chk.Equal("a", c.Servers[0].Host)
+		chk.Equal("b", c.Servers[1].Host)
+		chk.Equal([2]string{"", "primary"}, c.Servers[0].Tags)
+		chk.Len(c.Servers[1].Ports, 3)
+		chk.Nil(c.Servers[1].Ports[0])
+		chk.Equal(443, c.Servers[1].Ports[2].Number)
+		chk.Equal("c", (*c.Backup)[0].Host)
+		//
+		values, err := b.Fields([]string{"Servers_1_Host", "Servers_1_Ports_2_Number"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"b", 443}, values)
+		field, err := b.Field("Servers_0_Host")
+		chk.NoError(err)
+		chk.Equal("a", field.WriteValue.Interface())
+		assignables, err := b.Assignables([]string{"Servers_0_Host"}, nil)
+		chk.NoError(err)
+		chk.Equal(&c.Servers[0].Host, assignables[0])
+		//
+		for _, key := range []string{"Servers_x_Host", "Servers_0_Ports_Number", "Servers_101_Host", "Servers__Host"} {
+			chk.ErrorIs(b.Set(key, "x"), set.ErrUnknownField, key)
+		}
+		chk.Len(c.Servers, 2)
+	})
+	t.Run("prepare", func(t *testing.T) {
+		chk := assert.New(t)
+		var c Config
+		p, err := mapper.Prepare(&c)
+		chk.NoError(err)
+		chk.NoError(p.Plan("Servers_0_Host", "Servers_2_Host"))
+		chk.NoError(p.Set("a"))
+		chk.NoError(p.Set("c"))
+		chk.Equal([]Server{{Host: "a"}, {}, {Host: "c"}}, c.Servers)
+		chk.ErrorIs(p.Plan("Servers_0_Ports_Number"), set.ErrUnknownField)
+	})
+	t.Run("limits", func(t *testing.T) {
+		chk := assert.New(t)
+		var c Config
+		b, err := (&set.Mapper{Join: "_", IndexSlices: true}).Bind(&c)
+		chk.NoError(err)
+		for _, key := range []string{"Servers_5000000_Host", "Servers_99999999999999999999_Host", "Servers_01_Host"} {
+			chk.ErrorIs(b.Set(key, "x"), set.ErrUnknownField, key)
+		}
+		chk.Nil(c.Servers)
+		chk.ErrorIs(b.Set(fmt.Sprintf("Servers_%v_Host", set.DefaultMaxSliceIndex+1), "x"), set.ErrUnknownField)
+		chk.NoError(b.Set(fmt.Sprintf("Servers_%v_Host", set.DefaultMaxSliceIndex), "x"))
+		chk.Len(c.Servers, set.DefaultMaxSliceIndex+1)
+		//
+		// A negative MaxSliceIndex does not limit indexes.
+		var unlimited Config
+		b, err = (&set.Mapper{Join: "_", IndexSlices: true, MaxSliceIndex: -1}).Bind(&unlimited)
+		chk.NoError(err)
+		chk.NoError(b.Set(fmt.Sprintf("Servers_%v_Host", set.DefaultMaxSliceIndex+1), "x"))
+		chk.Len(unlimited.Servers, set.DefaultMaxSliceIndex+2)
+	})
+	t.Run("unmap", func(t *testing.T) {
+		chk := assert.New(t)
+		c := Config{Name: "cfg", Servers: []Server{{Host: "a", Ports: []*Port{{80}}}}}
+		m, err := mapper.Unmap(c)
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{
+			"Name":                     "cfg",
+			"Servers_0_Host":           "a",
+			"Servers_0_Ports_0_Number": 80,
+			"Servers_0_Tags_0":         "",
+			"Servers_0_Tags_1":         "",
+		}, m)
+		//
+		var other Config
+		b, err := mapper.Bind(&other)
+		chk.NoError(err)
+		for key, value := range m {
+			chk.NoError(b.Set(key, value))
+		}
+		chk.Equal(c, other)
+	})
+}
+
+func TestMapper_PromoteEmbedded(t *testing.T) {
+	type base struct {
+		ID int `json:"id"`
+	}
+	type Audit struct {
+		Created string `json:"created"`
+		Name    string `json:"name"`
+	}
+	type Owner struct {
+		Name string `json:"name"`
+	}
+	type Meta struct {
+		Version int `json:"version"`
+	}
+	type Inner struct {
+		Deep string `json:"deep"`
+		Kind string `json:"kind"`
+	}
+	type Middle struct {
+		Inner
+		Kind string `json:"kind"`
+	}
+	type Doc struct {
+		base
+		*Audit
+		Owner
+		Meta `json:"meta"`
+		Middle
+		Title string `json:"title"`
+	}
+	mapper := &set.Mapper{
+		Tags:            []string{"json"},
+		Join:            "_",
+		PromoteEmbedded: true,
+	}
+	t.Run("keys", func(t *testing.T) {
+		chk := assert.New(t)
+		mapping := mapper.Map(Doc{})
+		// Audit.Name and Owner.Name are at the same depth and cancel out; Middle.Kind is
+		// shallower than Inner.Kind.
+		chk.Equal([]string{"id", "created", "meta_version", "deep", "kind", "title"}, mapping.Keys)
+		chk.Equal([]int{4, 1}, mapping.Get("kind"))
+		chk.Equal([]int{4, 0, 0}, mapping.Get("deep"))
+		//
+		// The keys are the same as encoding/json.
+		buf, err := json.Marshal(Doc{Audit: &Audit{}})
+		chk.NoError(err)
+		var m map[string]interface{}
+		chk.NoError(json.Unmarshal(buf, &m))
+		keys := []string{}
+		for key, value := range m {
+			if nested, ok := value.(map[string]interface{}); ok {
+				for nestedKey := range nested {
+					keys = append(keys, key+"_"+nestedKey)
+				}
+				continue
+			}
+			keys = append(keys, key)
+		}
+		chk.ElementsMatch(mapping.Keys, keys)
+	})
+	t.Run("tagged wins", func(t *testing.T) {
+		type A struct {
+			Name string
+		}
+		type B struct {
+			Name string `json:"Name"`
+		}
+		type T struct {
+			A
+			B
+		}
+		chk := assert.New(t)
+		mapping := mapper.Map(T{})
+		chk.Equal([]string{"Name"}, mapping.Keys)
+		chk.Equal([]int{1, 0}, mapping.Get("Name"))
+		//
+		type Node struct {
+			*Node
+			Name string
+		}
+		chk.Equal([]string{"Name"}, mapper.Map(Node{}).Keys)
+	})
+	t.Run("tagged fields only", func(t *testing.T) {
+		chk := assert.New(t)
+		tagged := &set.Mapper{
+			Tags:             []string{"json"},
+			Join:             "_",
+			PromoteEmbedded:  true,
+			TaggedFieldsOnly: true,
+		}
+		chk.Equal([]string{"id", "created", "meta_version", "deep", "kind", "title"}, tagged.Map(Doc{}).Keys)
+	})
+	t.Run("bind", func(t *testing.T) {
+		chk := assert.New(t)
+		var doc Doc
+		b, err := mapper.Bind(&doc)
+		chk.NoError(err)
+		chk.NoError(b.Set("id", 42))
+		chk.NoError(b.Set("created", "today"))
+		chk.NoError(b.Set("meta_version", "2"))
+		chk.NoError(b.Set("kind", "middle"))
+		chk.NoError(b.Set("deep", "inner"))
+		chk.Equal(42, doc.ID)
+		chk.Equal("today", doc.Audit.Created)
+		chk.Equal(2, doc.Version)
+		chk.Equal("middle", doc.Middle.Kind)
+		chk.Equal("inner", doc.Deep)
+		chk.ErrorIs(b.Set("name", "x"), set.ErrUnknownField)
+	})
+	t.Run("unmap", func(t *testing.T) {
+		chk := assert.New(t)
+		doc := Doc{Title: "t"}
+		doc.ID = 1
+		m, err := mapper.Unmap(doc)
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{"id": 1, "meta_version": 0, "deep": "", "kind": "", "title": "t"}, m)
+		doc.Audit = &Audit{Created: "now"}
+		m, err = mapper.UnmapNested(doc)
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{"id": 1, "created": "now", "meta": map[string]interface{}{"version": 0}, "deep": "", "kind": "", "title": "t"}, m)
+	})
+}
+
+func TestMapper_Unmap(t *testing.T) {
+	type Common struct {
+		Id int `json:"id"`
+	}
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Phone struct {
+		Kind   string `json:"kind"`
+		Number string `json:"number"`
+	}
+	type Person struct {
+		Common
+		Name     string            `json:"name"`
+		Nick     *string           `json:"nick"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Born     time.Time         `json:"born"`
+		Address  Address           `json:"address"`
+		Work     *Address          `json:"work"`
+		Phones   []Phone           `json:"phones"`
+		Parents  []*Person         `json:"parents"`
+		Ignore   chan int          `json:"ignore"`
+		internal int
+	}
+	born := time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC)
+	person := Person{
+		Common:  Common{Id: 1},
+		Name:    "Bob",
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"x": "y"},
+		Born:    born,
+		Address: Address{Street: "Main", City: "Springfield"},
+		Phones:  []Phone{{Kind: "home", Number: "555"}},
+		Parents: []*Person{{Name: "Alice"}, nil},
+	}
+	//
+	t.Run("flat", func(t *testing.T) {
+		chk := assert.New(t)
+		mapper := &set.Mapper{
+			Elevated: set.NewTypeList(Common{}),
+			Join:     "_",
+		}
+		m, err := mapper.Unmap(&person)
+		chk.NoError(err)
+		chk.Equal(1, m["Id"])
+		chk.Equal("Bob", m["Name"])
+		chk.Nil(m["Nick"])
+		chk.Contains(m, "Nick")
+		chk.Equal([]string{"a", "b"}, m["Tags"])
+		chk.Equal(map[string]string{"x": "y"}, m["Labels"])
+		chk.Equal(born, m["Born"])
+		chk.Equal("Main", m["Address_Street"])
+		chk.Equal("Springfield", m["Address_City"])
+		chk.NotContains(m, "Work_Street")
+		chk.NotContains(m, "Ignore")
+		chk.NotContains(m, "internal")
+		chk.Equal([]map[string]interface{}{{"Kind": "home", "Number": "555"}}, m["Phones"])
+		parents := m["Parents"].([]map[string]interface{})
+		chk.Len(parents, 2)
+		chk.Equal("Alice", parents[0]["Name"])
+		chk.Nil(parents[1])
+		//
+		// Keys for mapped fields match those generated by Map; Work is a nil pointer.
+		mapping := mapper.Map(&person)
+		for _, key := range mapping.Keys {
+			if !strings.HasPrefix(key, "Work_") {
+				chk.Contains(m, key)
+			}
+		}
+		//
+		// Round trip through BoundMapping.
+		var dst Person
+		b, err := mapper.Bind(&dst)
+		chk.NoError(err)
+		for _, key := range mapping.Keys {
+			if value, ok := m[key]; ok {
+				chk.NoError(b.Set(key, value), key)
+			}
+		}
+		chk.Equal(person.Address, dst.Address)
+		chk.Equal(person.Name, dst.Name)
+		chk.Equal(person.Born, dst.Born)
+	})
+	t.Run("nested", func(t *testing.T) {
+		chk := assert.New(t)
+		nick := "bobby"
+		p := person
+		p.Nick = &nick
+		p.Work = &Address{City: "Shelbyville"}
+		mapper := &set.Mapper{
+			Tags:      []string{"json"},
+			Transform: strings.ToLower,
+		}
+		m, err := mapper.UnmapNested(p)
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{"id": 1}, m["common"])
+		chk.Equal("bobby", m["nick"])
+		chk.Equal(map[string]interface{}{"street": "Main", "city": "Springfield"}, m["address"])
+		chk.Equal(map[string]interface{}{"street": "", "city": "Shelbyville"}, m["work"])
+		chk.Equal([]map[string]interface{}{{"kind": "home", "number": "555"}}, m["phones"])
+		//
+		// Elevated types are merged into their parent.
+		mapper = &set.Mapper{
+			Elevated: set.NewTypeList(Common{}),
+		}
+		m, err = mapper.UnmapNested(&p)
+		chk.NoError(err)
+		chk.Equal(1, m["Id"])
+		chk.NotContains(m, "Common")
+	})
+	t.Run("recursive", func(t *testing.T) {
+		type Node struct {
+			Name string
+			Next *Node
+		}
+		chk := assert.New(t)
+		a := &Node{Name: "a"}
+		a.Next = &Node{Name: "b", Next: a}
+		//
+		m, err := (&set.Mapper{Join: "_", MaxDepth: 2}).Unmap(a)
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{"Name": "a", "Next_Name": "b"}, m)
+		//
+		m, err = (&set.Mapper{MaxDepth: 5}).UnmapNested(a)
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{"Name": "a", "Next": map[string]interface{}{"Name": "b"}}, m)
+	})
+	t.Run("errors", func(t *testing.T) {
+		chk := assert.New(t)
+		_, err := set.DefaultMapper.Unmap(42)
+		chk.ErrorIs(err, set.ErrUnsupported)
+		_, err = set.DefaultMapper.UnmapNested((*Person)(nil))
+		chk.ErrorIs(err, set.ErrUnsupported)
+		_, err = set.DefaultMapper.Unmap(reflect.ValueOf(person))
+		chk.NoError(err)
+	})
+}
+
 func TestMapperCodeCoverage(t *testing.T) {
 	chk := assert.New(t)
 	{ // Tests case where mapper is empty when calling Mapping.Lookup ~AND~ Mapping.String
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"unsafe"
 )
 
 // PathOffsetSegment describes a segment of a Path when traversed via pointer arithmetic.
@@ -88,3 +89,35 @@ func (o PathOffsets) String() string {
 	}
 	return strings.Join(parts, " ∪ ")
 }
+
+// Pointer returns the address of the field described by o within the struct located at base;
+// base must point to a value of the struct type that was used to create o.
+//
+// Pointers between segments are dereferenced.  If one of them is nil it is instantiated when
+// alloc is true; otherwise Pointer returns nil.  Pointer returns nil if o is empty.
+func (o PathOffsets) Pointer(base unsafe.Pointer, alloc bool) unsafe.Pointer {
+	last := len(o) - 1
+	if last < 0 {
+		return nil
+	}
+	for _, segment := range o[:last] {
+		p := unsafe.Pointer(uintptr(base) + segment.Offset)
+		for n := 0; n < segment.IndirectionLevel; n++ {
+			if *(*unsafe.Pointer)(p) == nil {
+				if !alloc {
+					return nil
+				}
+				// NB  Allocate through reflect so the new memory has the correct type; the type is
+				//     only walked here to keep the common non-nil case cheap.
+				T := segment.Type
+				for k := 0; k < n; k++ {
+					T = T.Elem()
+				}
+				reflect.NewAt(T, p).Elem().Set(reflect.New(T.Elem()))
+			}
+			p = *(*unsafe.Pointer)(p)
+		}
+		base = p
+	}
+	return unsafe.Pointer(uintptr(base) + o[last].Offset)
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 
@@ -22,6 +23,79 @@ func TestPath(t *testing.T) {
 	})
 }
 
+func TestReflectPath_Name(t *testing.T) {
+	type Money struct {
+		Amount float64
+	}
+	type Embedded struct {
+		Note string
+	}
+	type Line struct {
+		Qty   int
+		Price **Money
+	}
+	type Order struct {
+		Embedded
+		ID    int
+		Total *Money
+		Lines [3]*Line
+	}
+	tree := path.Stat(&Order{})
+	chk := assert.New(t)
+	for _, name := range []string{"ID", "Total.Amount", "Embedded.Note"} {
+		chk.Equal(name, tree.Leaves[name].ReflectPath().Name(reflect.TypeOf(&Order{})))
+	}
+	//
+	lines := tree.Leaves["Lines"].ReflectPath()
+	chk.Equal("Lines", lines.Name(reflect.TypeOf(Order{})))
+	lineTree := path.Stat(Line{})
+	price := lineTree.Leaves["Price.Amount"].ReflectPath()
+	lines.Elem = &path.ReflectElem{Index: 2, Path: &price}
+	chk.Equal("Lines[2].Price.Amount", lines.Name(reflect.TypeOf(Order{})))
+	lines.Elem = &path.ReflectElem{Index: 1}
+	chk.Equal("Lines[1]", lines.Name(reflect.TypeOf(Order{})))
+	//
+	chk.Equal("", path.ReflectPath{Last: -1}.Name(reflect.TypeOf(Order{})))
+}
+
+func TestPathOffsets_Pointer(t *testing.T) {
+	type C struct {
+		N int
+	}
+	type B struct {
+		C **C
+	}
+	type A struct {
+		S string
+		B B
+		P *B
+	}
+	chk := assert.New(t)
+	tree := path.Stat(A{})
+	var a A
+	base := unsafe.Pointer(&a)
+	//
+	chk.Equal(unsafe.Pointer(&a.S), path.PathOffsets(tree.Leaves["S"].PathwayOffsets).Pointer(base, false))
+	chk.Equal(unsafe.Pointer(nil), path.PathOffsets(nil).Pointer(base, true))
+	//
+	offsets := path.PathOffsets(tree.Leaves["B.C.N"].PathwayOffsets)
+	chk.Equal(unsafe.Pointer(nil), offsets.Pointer(base, false))
+	chk.Nil(a.B.C)
+	ptr := offsets.Pointer(base, true)
+	chk.NotNil(a.B.C)
+	chk.NotNil(*a.B.C)
+	chk.Equal(unsafe.Pointer(&(*a.B.C).N), ptr)
+	*(*int)(ptr) = 42
+	chk.Equal(42, (*a.B.C).N)
+	chk.Equal(ptr, offsets.Pointer(base, false))
+	//
+	offsets = path.PathOffsets(tree.Leaves["P.C.N"].PathwayOffsets)
+	chk.Equal(unsafe.Pointer(nil), offsets.Pointer(base, false))
+	chk.Nil(a.P)
+	ptr = offsets.Pointer(base, true)
+	chk.Equal(unsafe.Pointer(&(*a.P.C).N), ptr)
+}
+
 func TestPath_TypeAll(t *testing.T) {
 	type This struct {
 		A int
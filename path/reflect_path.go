@@ -2,6 +2,8 @@ package path
 
 import (
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 // ReflectPath contains the bare minimum information to traverse
@@ -16,6 +18,131 @@ type ReflectPath struct {
 	HasPointer bool
 	Index      []int
 	Last       int
+
+	// Elem is non-nil when the field described by Index+Last is an array or slice, or pointer
+	// chain to an array or slice, and the path continues into one of its elements.
+	Elem *ReflectElem
+
+	// Offsets is an optional description of the same pathway for traversal by pointer
+	// arithmetic; it is copied from Path.PathwayOffsets by creators that opt in to unsafe
+	// access and is always nil when Elem is non-nil.
+	Offsets PathOffsets
+}
+
+// ReflectElem continues a ReflectPath into an element of an array or slice.
+type ReflectElem struct {
+	// Index is the element's index into the array or slice.
+	Index int
+
+	// Slice is true when the element belongs to a slice instead of an array.  Slices are
+	// grown as needed so that Index is in range.
+	Slice bool
+
+	// Path continues into a field of the element when the element is a struct, pointer-to-struct,
+	// or pointer chain to struct.  When Path is nil the element itself is the destination.
+	Path *ReflectPath
+}
+
+// Value accepts the array or slice, or pointer chain to either, reached by the owning ReflectPath
+// and returns the element at Index or the field described by Path.
+//
+// Nil pointers along the way are instantiated and slices are grown to a length of Index+1 if
+// they are shorter.
+func (e ReflectElem) Value(v reflect.Value) reflect.Value {
+	for ; v.Kind() == reflect.Ptr; v = v.Elem() {
+		if v.IsNil() && v.CanSet() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+	}
+	if e.Slice && v.Len() <= e.Index && v.CanSet() {
+		n := e.Index + 1 - v.Len()
+		v.Set(reflect.AppendSlice(v, reflect.MakeSlice(v.Type(), n, n)))
+	}
+	v = v.Index(e.Index)
+	if e.Path == nil {
+		return v
+	}
+	for ; v.Kind() == reflect.Ptr; v = v.Elem() {
+		if v.IsNil() && v.CanSet() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+	}
+	return e.Path.Value(v)
+}
+
+// Read is the read-only counterpart of Value; it never instantiates nil pointers or grows
+// slices and works with values that are not addressable.  ok is false if a nil pointer is
+// reached or Index is out of range for a slice.
+func (e ReflectElem) Read(v reflect.Value) (rv reflect.Value, ok bool) {
+	for ; v.Kind() == reflect.Ptr; v = v.Elem() {
+		if v.IsNil() {
+			return v, false
+		}
+	}
+	if e.Index >= v.Len() {
+		return v, false
+	}
+	v = v.Index(e.Index)
+	if e.Path == nil {
+		return v, true
+	}
+	for ; v.Kind() == reflect.Ptr; v = v.Elem() {
+		if v.IsNil() {
+			return v, false
+		}
+	}
+	return e.Path.Read(v)
+}
+
+// Indexed returns a copy of p where the Index of each slice element, in order of traversal,
+// is replaced by the next value in indexes.  ok is false if the number of slice elements in
+// p is not len(indexes).
+func (p ReflectPath) Indexed(indexes []int) (rv ReflectPath, ok bool) {
+	if p.Elem == nil {
+		return p, len(indexes) == 0
+	}
+	elem := *p.Elem
+	if elem.Slice {
+		if len(indexes) == 0 {
+			return p, false
+		}
+		elem.Index, indexes = indexes[0], indexes[1:]
+	}
+	if elem.Path != nil {
+		next, ok := elem.Path.Indexed(indexes)
+		if !ok {
+			return p, false
+		}
+		elem.Path = &next
+	} else if len(indexes) != 0 {
+		return p, false
+	}
+	p.Elem = &elem
+	return p, true
+}
+
+// Read is the read-only counterpart of Value; it never instantiates nil pointers or grows
+// slices and works with values that are not addressable.  ok is false if a nil pointer is
+// reached before the leaf field or a slice element is out of range.
+func (p ReflectPath) Read(v reflect.Value) (rv reflect.Value, ok bool) {
+	if p.HasPointer {
+		for _, n := range p.Index {
+			v = v.Field(n)
+			for ; v.Kind() == reflect.Ptr; v = v.Elem() {
+				if v.IsNil() {
+					return v, false
+				}
+			}
+		}
+	} else {
+		for _, n := range p.Index {
+			v = v.Field(n)
+		}
+	}
+	if p.Elem != nil {
+		return p.Elem.Read(v.Field(p.Last))
+	}
+	return v.Field(p.Last), true
 }
 
 // Value accepts an originating struct value and traverses Index+Last to
@@ -43,6 +170,9 @@ func (p ReflectPath) Value(v reflect.Value) reflect.Value {
 			v = v.Field(n)
 		}
 	}
+	if p.Elem != nil {
+		return p.Elem.Value(v.Field(p.Last))
+	}
 	return v.Field(p.Last)
 	// NB  If p.Index stored the full indeces and p.Last was not a struct
 	//     member then the above loops would be written as:
@@ -69,3 +199,42 @@ func (p ReflectPath) Value(v reflect.Value) reflect.Value {
 	// This allows the [for...range] to skip the check for [if k < final]
 	// altogether.
 }
+
+// Name returns the Go path of the field described by p within T, such as Total.Amount or
+// Lines[1].Qty, in the same form as Path.PathwayName.  T is the struct type, or pointer
+// chain to it, used to create the original Path.
+//
+// Name uses reflect to walk T and is intended for error messages rather than hot paths.
+func (p ReflectPath) Name(T reflect.Type) string {
+	if p.Last < 0 {
+		return ""
+	}
+	var b strings.Builder
+	p.name(T, &b)
+	return b.String()
+}
+
+// name writes the Go path of p within T to b.
+func (p ReflectPath) name(T reflect.Type, b *strings.Builder) {
+	for _, n := range append(append([]int(nil), p.Index...), p.Last) {
+		for ; T.Kind() == reflect.Ptr; T = T.Elem() {
+			// Walk pointer chain to the struct.
+		}
+		field := T.Field(n)
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(field.Name)
+		T = field.Type
+	}
+	if p.Elem == nil {
+		return
+	}
+	for ; T.Kind() == reflect.Ptr; T = T.Elem() {
+		// Walk pointer chain to the array or slice.
+	}
+	b.WriteString("[" + strconv.Itoa(p.Elem.Index) + "]")
+	if p.Elem.Path != nil {
+		p.Elem.Path.name(T.Elem(), b)
+	}
+}
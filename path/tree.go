@@ -82,7 +82,38 @@ func (t Tree) StringIndent(indent string) string {
 // is a struct with no exported fields then it is a leaf.
 //
 // Branches are fields that are structs or embedded structs that can be traversed deeper.
+//
+// Stat is safe to call with self-referencing or mutually recursive types.  When a field's
+// type at the end of its pointer chain is already being traversed further up the pathway
+// the field is a back-reference; it is recorded as a branch and is not traversed further.
+// See StatDepth to traverse back-references a limited number of times.
 func Stat(v interface{}) Tree {
+	return StatDepth(v, 0)
+}
+
+// StatDepth is the same as Stat except back-references to types already being traversed
+// are followed up to depth times along any single pathway.
+//
+// Given:
+//	type Node struct {
+//		Name string
+//		Next *Node
+//	}
+// Stat(Node{}) and StatDepth(Node{}, 0) yield the leaf Name and the branch Next.
+// StatDepth(Node{}, 1) yields the leaves Name and Next.Name and the branches Next and Next.Next.
+func StatDepth(v interface{}, depth int) Tree {
+	return statDepth(v, depth, false)
+}
+
+// StatEmbedded is the same as StatDepth except embedded structs are traversed even when their
+// type is unexported; the exported fields promoted from them are included in the Tree.
+func StatEmbedded(v interface{}, depth int) Tree {
+	return statDepth(v, depth, true)
+}
+
+// statDepth powers StatDepth and StatEmbedded; when embedded is true embedded structs with
+// unexported types are traversed.
+func statDepth(v interface{}, depth int, embedded bool) Tree {
 	t := Tree{
 		Leaves:   map[string]Path{},
 		Branches: map[string]Path{},
@@ -94,6 +125,10 @@ func Stat(v interface{}) Tree {
 		Path
 	}
 	//
+	// active counts the struct types currently being traversed along the pathway; it
+	// is used to detect back-references in recursive types.
+	active := map[reflect.Type]int{}
+	//
 	var stat func(v interface{}, parent Meta) int
 	stat = func(v interface{}, parent Meta) int {
 		var T reflect.Type
@@ -109,12 +144,16 @@ func Stat(v interface{}) Tree {
 		if T.Kind() != reflect.Struct {
 			return 0
 		}
+		active[T]++
+		defer func() { active[T]-- }()
 		//
 		fields := make([]Path, 0, T.NumField())
 		for fieldIndex, size := 0, T.NumField(); fieldIndex < size; fieldIndex++ {
 			F := T.Field(fieldIndex)
-			if F.PkgPath != "" {
-				continue // PkgPath is non-empty for private fields.
+			if F.PkgPath != "" && !(embedded && F.Anonymous && F.Type.Kind() == reflect.Struct) {
+				// PkgPath is non-empty for private fields; embedded private structs are still
+				// traversed by StatEmbedded because their exported fields are promoted.
+				continue
 			}
 			m := Meta{
 				PtrDeref:   0,
@@ -190,6 +229,13 @@ func Stat(v interface{}) Tree {
 			}
 			fields = append(fields, m.Path)
 			//
+			// A back-reference to a type already being traversed is a branch that
+			// is not traversed further once depth is exhausted.
+			if m.EndType.Kind() == reflect.Struct && active[m.EndType] > depth {
+				t.Branches[m.PathwayName] = m.Path
+				continue
+			}
+			//
 			// Process this field type.
 			children := stat(m.EndType, m)
 			if children == 0 {
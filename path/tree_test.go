@@ -1,8 +1,11 @@
 package path_test
 
 import (
+	"sort"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/nofeaturesonlybugs/set/path"
 )
 
@@ -30,4 +33,74 @@ func TestTree(t *testing.T) {
 		tree := path.Stat(Empty{})
 		tree.Slice()
 	})
+	t.Run("recursive", func(t *testing.T) {
+		chk := assert.New(t)
+		type Node struct {
+			Name string
+			Next *Node
+		}
+		tree := path.Stat(Node{})
+		chk.Contains(tree.Leaves, "Name")
+		chk.Contains(tree.Branches, "Next")
+		chk.Len(tree.Leaves, 1)
+		chk.Len(tree.Branches, 1)
+		//
+		tree = path.StatDepth(Node{}, 2)
+		chk.Contains(tree.Leaves, "Next.Next.Name")
+		chk.Contains(tree.Branches, "Next.Next.Next")
+		chk.Len(tree.Leaves, 3)
+		chk.Len(tree.Branches, 3)
+		chk.Equal([][]int{{1}, {1}, {0}}, tree.Leaves["Next.Next.Name"].PathwayIndex)
+	})
+	t.Run("mutually recursive", func(t *testing.T) {
+		chk := assert.New(t)
+		type Child struct {
+			Name   string
+			Parent interface{}
+		}
+		type Parent struct {
+			Name     string
+			Children []Child
+			Child    *struct {
+				Name   string
+				Parent *Parent
+			}
+		}
+		tree := path.Stat(&Parent{})
+		chk.Contains(tree.Leaves, "Name")
+		chk.Contains(tree.Leaves, "Children")
+		chk.Contains(tree.Leaves, "Child.Name")
+		chk.Contains(tree.Branches, "Child")
+		chk.Contains(tree.Branches, "Child.Parent")
+		chk.NotContains(tree.Leaves, "Child.Parent.Name")
+	})
+	t.Run("unexported embedded", func(t *testing.T) {
+		chk := assert.New(t)
+		type inner struct {
+			Hidden string
+		}
+		type Outer struct {
+			inner
+			Name string
+		}
+		// Stat and StatDepth skip unexported embedded structs.
+		for _, tree := range []path.Tree{path.Stat(Outer{}), path.StatDepth(Outer{}, 1)} {
+			chk.Equal([]string{"Name"}, treeNames(tree.Leaves))
+			chk.Empty(tree.Branches)
+		}
+		tree := path.StatEmbedded(Outer{}, 0)
+		chk.Equal([]string{"Name", "inner.Hidden"}, treeNames(tree.Leaves))
+		chk.Equal([]string{"inner"}, treeNames(tree.Branches))
+		chk.Equal([][]int{{0, 0}}, [][]int(tree.Leaves["inner.Hidden"].PathwayIndex))
+	})
+}
+
+// treeNames returns the sorted keys of paths.
+func treeNames(paths map[string]path.Path) []string {
+	var rv []string
+	for name := range paths {
+		rv = append(rv, name)
+	}
+	sort.Strings(rv)
+	return rv
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"time"
+	"unsafe"
 
 	"github.com/nofeaturesonlybugs/set/path"
 )
@@ -45,14 +46,30 @@ type PreparedMapping struct {
 	valid bool
 	err   error // Must always be nil, ErrNoPlan, or instance of pkgerr{}
 
+	// readOnly is true when created by Mapper.PrepareReadOnly; valid is then always false, err
+	// always wraps ErrReadOnly, and planned is true after a successful call to Plan.
+	readOnly bool
+	planned  bool
+
 	// plan is the slice of steps created by Plan and
-	// k is the index into plan for the next step.
+	// k is the index into plan for the next step;
+	// keys contains the fields passed to Plan for each step.
 	k    int
 	plan []path.ReflectPath
+	keys []string
 
 	// NB	paths is obtained from Mapping.Paths and is not a copy.
 	//      Treat as read only.
 	paths map[string]path.ReflectPath
+
+	// resolver finds keys that are not exact matches for a key in paths; it is nil unless
+	// Mapper.IndexSlices is true or Mapper.Normalize is set.
+	resolver *keyResolver
+
+	// converters is the ConverterRegistry from the Mapper that created the PreparedMapping.
+	// sqlAdapters is Mapper.SQLAdapters from the Mapper that created the PreparedMapping.
+	converters  *ConverterRegistry
+	sqlAdapters bool
 }
 
 // Assignables returns a slice of pointers to the fields in the currently bound
@@ -66,6 +83,9 @@ type PreparedMapping struct {
 //
 // An example use-case would be obtaining a slice of pointers for Rows.Scan() during database
 // query results.
+//
+// If the Mapper that created the PreparedMapping has SQLAdapters enabled then the returned values
+// implement sql.Scanner instead of being pointers; see Mapper.SQLAdapters.
 func (p PreparedMapping) Assignables(rv []interface{}) ([]interface{}, error) {
 	if !p.valid {
 		if p.err == ErrNoPlan {
@@ -93,6 +113,13 @@ func (p PreparedMapping) Assignables(rv []interface{}) ([]interface{}, error) {
 			}
 		}
 		v = v.Field(step.Last) // NB  End manual inline of path.ReflectPath.Value
+		if step.Elem != nil {
+			v = step.Elem.Value(v)
+		}
+		if p.sqlAdapters {
+			rv[fieldN] = newFieldScanner(rv[fieldN], v, p.converters)
+			continue
+		}
 		rv[fieldN] = v.Addr().Interface()
 	}
 	return rv, nil
@@ -105,13 +132,19 @@ func (p PreparedMapping) Assignables(rv []interface{}) ([]interface{}, error) {
 // it can be obtained by calling Copy on the cached PreparedMapping for that type.
 func (p PreparedMapping) Copy() PreparedMapping {
 	return PreparedMapping{
-		top:   p.top,
-		value: p.value,
-		valid: p.valid,
-		err:   p.err,
-		k:     p.k,
-		plan:  append([]path.ReflectPath(nil), p.plan...),
-		paths: p.paths,
+		top:         p.top,
+		value:       p.value,
+		valid:       p.valid,
+		err:         p.err,
+		readOnly:    p.readOnly,
+		planned:     p.planned,
+		k:           p.k,
+		plan:        append([]path.ReflectPath(nil), p.plan...),
+		keys:        append([]string(nil), p.keys...),
+		paths:       p.paths,
+		resolver:    p.resolver,
+		converters:  p.converters,
+		sqlAdapters: p.sqlAdapters,
 	}
 }
 
@@ -162,6 +195,9 @@ func (p *PreparedMapping) Field() (Value, error) {
 		}
 	}
 	v = v.Field(step.Last) // NB  End manual inline of path.ReflectPath.Value
+	if step.Elem != nil {
+		v = step.Elem.Value(v)
+	}
 	//
 	return V(v), nil
 }
@@ -173,10 +209,14 @@ func (p *PreparedMapping) Field() (Value, error) {
 // passed as the argument to Fields.  If non-nil it is assumed len(plan) == len(rv)
 // and failure to provide an appropriately sized non-nil slice will cause a panic.
 //
-// During traversal this method will allocate struct fields that are nil pointers.
+// During traversal this method will allocate struct fields that are nil pointers; use
+// ReadFields to read without allocating.
 //
 // An example use-case would be obtaining a slice of query arguments by column name during
 // database queries.
+//
+// If the Mapper that created the PreparedMapping has SQLAdapters enabled then the returned values
+// are suitable as database/sql query arguments; see Mapper.SQLAdapters.
 func (p PreparedMapping) Fields(rv []interface{}) ([]interface{}, error) {
 	if !p.valid {
 		if p.err == ErrNoPlan {
@@ -188,6 +228,10 @@ func (p PreparedMapping) Fields(rv []interface{}) ([]interface{}, error) {
 		rv = make([]interface{}, len(p.plan))
 	}
 	for fieldN, step := range p.plan {
+		if step.Offsets != nil && !p.sqlAdapters {
+			rv[fieldN] = unsafeRead(unsafe.Pointer(p.value.UnsafeAddr()), step.Offsets, true)
+			continue
+		}
 		v := p.value
 		if step.HasPointer { // NB  Begin manual inline of path.ReflectPath.Value
 			for _, n := range step.Index {
@@ -204,6 +248,16 @@ func (p PreparedMapping) Fields(rv []interface{}) ([]interface{}, error) {
 			}
 		}
 		v = v.Field(step.Last) // NB  End manual inline of path.ReflectPath.Value
+		if step.Elem != nil {
+			v = step.Elem.Value(v)
+		}
+		if p.sqlAdapters {
+			var err error
+			if rv[fieldN], err = driverValue(v); err != nil {
+				return rv, pkgerr{Err: err, CallSite: "PreparedMapping.Fields", Context: fmt.Sprintf("field %v of plan in type %v", fieldN, p.top)}
+			}
+			continue
+		}
 		// NB  The value we want is v.Interface() which performs a number of allocations for built-in primitives.
 		//     If we switch off v's type as a pointer and it is a primitive we can skip the allocations.
 		switch ptr := v.Addr().Interface().(type) {
@@ -244,6 +298,55 @@ func (p PreparedMapping) Fields(rv []interface{}) ([]interface{}, error) {
 	return rv, nil
 }
 
+// ReadFields is the read-only counterpart of Fields; it returns a slice of values of the fields
+// in the currently bound struct in the order specified by the last call to Plan.
+//
+// ReadFields never allocates nil pointers or otherwise modifies the bound struct.  If a nil
+// pointer is reached before a field, or the field is an element beyond the length of a slice,
+// then its value is nil.  When the PreparedMapping was created by Mapper.PrepareReadOnly the
+// bound value does not need to be addressable.
+//
+// The rv argument and SQLAdapters behave the same as for Fields.
+func (p PreparedMapping) ReadFields(rv []interface{}) ([]interface{}, error) {
+	if p.readOnly {
+		if !p.planned {
+			return rv, pkgerr{Err: ErrNoPlan, CallSite: "PreparedMapping.ReadFields", Hint: "call PreparedMapping.Plan to prepare access plan for " + p.top.String()}
+		}
+	} else if !p.valid {
+		if p.err == ErrNoPlan {
+			return rv, pkgerr{Err: ErrNoPlan, CallSite: "PreparedMapping.ReadFields", Hint: "call PreparedMapping.Plan to prepare access plan for " + p.top.String()}
+		}
+		return rv, p.err.(pkgerr).WithCallSite("PreparedMapping.ReadFields")
+	}
+	if rv == nil {
+		rv = make([]interface{}, len(p.plan))
+	}
+	for fieldN, step := range p.plan {
+		var v reflect.Value
+		var ok bool
+		if step.Offsets != nil && !p.sqlAdapters && p.value.Kind() == reflect.Struct && p.value.CanAddr() {
+			rv[fieldN] = unsafeRead(unsafe.Pointer(p.value.UnsafeAddr()), step.Offsets, false)
+			continue
+		}
+		if p.value.Kind() == reflect.Struct {
+			v, ok = step.Read(p.value)
+		}
+		if !ok {
+			rv[fieldN] = nil
+			continue
+		}
+		if p.sqlAdapters {
+			var err error
+			if rv[fieldN], err = driverValue(v); err != nil {
+				return rv, pkgerr{Err: err, CallSite: "PreparedMapping.ReadFields", Context: fmt.Sprintf("field %v of plan in type %v", fieldN, p.top)}
+			}
+			continue
+		}
+		rv[fieldN] = readInterface(v)
+	}
+	return rv, nil
+}
+
 // Plan builds the field access plan and must be called before any other methods that
 // return an error.
 //
@@ -252,9 +355,14 @@ func (p PreparedMapping) Fields(rv []interface{}) ([]interface{}, error) {
 //	2. Resets the internal plan-step counter.
 //
 // If an unknown field is specified then ErrUnknownField is wrapped with the field name
-// and the internal error is set to ErrPlanInvalid.
+// and the internal error is set to ErrPlanInvalid.  If the Mapper has Normalize set and a
+// field matches more than one key after normalization then ErrAmbiguousField is wrapped
+// instead.
+//
+// A PreparedMapping created by Mapper.PrepareReadOnly keeps its internal error wrapping
+// ErrReadOnly and its plan is only used by ReadFields.
 func (p *PreparedMapping) Plan(fields ...string) error {
-	if p.err != nil && errors.Is(p.err, ErrReadOnly) {
+	if p.err != nil && errors.Is(p.err, ErrReadOnly) && !p.readOnly {
 		return p.err.(pkgerr).WithCallSite("PreparedMapping.Plan")
 	}
 	//
@@ -263,26 +371,33 @@ func (p *PreparedMapping) Plan(fields ...string) error {
 		p.plan = make([]path.ReflectPath, 0, max)
 	}
 	p.plan = p.plan[0:0]
+	p.keys = append(p.keys[0:0], fields...)
 	p.valid = false
+	p.planned = false
 	//
 	for _, field := range fields {
 		path, ok := p.paths[field]
 		if !ok {
-			context := "field [" + field + "] not found in type " + p.top.String()
-			p.err = pkgerr{
-				Err:      ErrNoPlan,
-				CallSite: "PreparedMapping.Plan",
-				Context:  context + " during plan creation",
-			}
-			return pkgerr{
-				Err:      ErrUnknownField,
-				Context:  context,
-				CallSite: "PreparedMapping.Plan",
+			var err error
+			if path, err = p.resolver.lookup(p.paths, field, p.top, "PreparedMapping.Plan"); err != nil {
+				if p.readOnly {
+					return err
+				}
+				p.err = pkgerr{
+					Err:      ErrNoPlan,
+					CallSite: "PreparedMapping.Plan",
+					Context:  err.(pkgerr).Context + " during plan creation",
+				}
+				return err
 			}
 		}
 		p.plan = append(p.plan, path)
 	}
 	//
+	if p.readOnly {
+		p.planned = true
+		return nil
+	}
 	p.err = nil
 	p.k = -1
 	p.valid = true
@@ -290,6 +405,33 @@ func (p *PreparedMapping) Plan(fields ...string) error {
 	return nil
 }
 
+// PlanKnown is Plan for the fields that are known and skips the fields that are unknown.  It
+// returns the index into fields of each planned field in known and of each skipped field in
+// unknown; the PreparedMapping is planned for fields[known[0]], fields[known[1]], and so on.
+//
+// PlanKnown is useful when fields come from an outside source such as a CSV header or the columns
+// of a database query.
+//
+// Only fields returning ErrUnknownField are skipped.  Other errors, such as ErrAmbiguousField, stop
+// PlanKnown and are returned with the known and unknown fields found so far; the field causing the
+// error is fields[len(known)+len(unknown)].
+func (p *PreparedMapping) PlanKnown(fields ...string) (known []int, unknown []int, err error) {
+	for k, field := range fields {
+		if err = p.Plan(field); errors.Is(err, ErrUnknownField) {
+			unknown = append(unknown, k)
+			continue
+		} else if err != nil {
+			return known, unknown, err
+		}
+		known = append(known, k)
+	}
+	planned := make([]string, len(known))
+	for k, index := range known {
+		planned[k] = fields[index]
+	}
+	return known, unknown, p.Plan(planned...)
+}
+
 // Rebind will replace the currently bound value with the new variable v.
 //
 // v must have the same type as the original value used to create the PreparedMapping
@@ -298,7 +440,7 @@ func (p *PreparedMapping) Plan(fields ...string) error {
 // As a convenience Rebind allows v to be an instance of reflect.Value.  This prevents
 // unnecessary calls to reflect.Value.Interface().
 func (p *PreparedMapping) Rebind(v interface{}) {
-	if p.err != nil && errors.Is(p.err, ErrReadOnly) {
+	if p.err != nil && errors.Is(p.err, ErrReadOnly) && !p.readOnly {
 		return
 	}
 	//
@@ -314,6 +456,10 @@ func (p *PreparedMapping) Rebind(v interface{}) {
 	if p.top != T {
 		panic(fmt.Sprintf("mismatching types during Rebind; have %v and got %T", p.top.String(), v)) // TODO ErrRebind maybe?
 	}
+	if p.readOnly {
+		p.value = readOnlyValue(rv)
+		return
+	}
 	//
 	if p.valid {
 		// Only clear previous error if we are valid.
@@ -345,8 +491,10 @@ func (p *PreparedMapping) next() error {
 // fields in the same order as the last call to Plan.
 //
 // ErrPlanInvalid is returned if Plan has not been called.  If this call to Set
-// exceeds the length of the plan then ErrPlanExceeded is returned.  Other
-// errors from this package or standard library may also be returned.
+// exceeds the length of the plan then ErrPlanExceeded is returned.  If value can
+// not be assigned to the field the error wraps a *FieldError describing the key,
+// the Go path of the field, its type, and value.  Other errors from this package
+// or standard library may also be returned.
 func (p *PreparedMapping) Set(value interface{}) error {
 	if !p.valid {
 		if p.err == ErrNoPlan {
@@ -363,6 +511,10 @@ func (p *PreparedMapping) Set(value interface{}) error {
 	//
 	v := p.value
 	step := p.plan[p.k]
+	fast := !convertsSelf(p.converters, value)
+	if fast && step.Offsets != nil && unsafeSet(unsafe.Pointer(v.UnsafeAddr()), step.Offsets, value) {
+		return nil
+	}
 	if step.HasPointer { // NB  Begin manual inline of path.ReflectPath.Value
 		for _, n := range step.Index {
 			v = v.Field(n)
@@ -378,10 +530,13 @@ func (p *PreparedMapping) Set(value interface{}) error {
 		}
 	}
 	v = v.Field(step.Last) // NB  End manual inline of path.ReflectPath.Value
+	if step.Elem != nil {
+		v = step.Elem.Value(v)
+	}
 	//
 	// If the types are directly equatable then we might be able to avoid creating a V(fieldValue),
 	// which will cut down our allocations and increase speed.
-	if v.Type() == reflect.TypeOf(value) {
+	if fast && v.Type() == reflect.TypeOf(value) {
 		switch tt := value.(type) {
 		case bool:
 			v.SetBool(tt)
@@ -430,12 +585,9 @@ func (p *PreparedMapping) Set(value interface{}) error {
 	//
 	// If the type-switch above didn't hit then we'll coerce the
 	// fieldValue to a Value and use our swiss-army knife Value.To().
-	err = V(v).To(value)
+	err = V(v).to(value, p.converters, false)
 	if err != nil {
-		err = pkgerr{
-			Err:      err,
-			CallSite: "PreparedMapping.Set",
-		}
+		err = setError(err, "PreparedMapping.Set", p.top, p.keys[p.k], step, v.Type(), value)
 		if p.err == nil {
 			p.err = err
 		}
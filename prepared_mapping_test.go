@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/coerce"
 )
 
 func TestPreparedMapping_Assignables(t *testing.T) {
@@ -278,6 +279,64 @@ func TestPreparedMapping_Err(t *testing.T) {
 	})
 }
 
+func TestPreparedMapping_SetFieldError(t *testing.T) {
+	type Money struct {
+		Amount float64
+	}
+	type Line struct {
+		Qty int
+	}
+	type Order struct {
+		ID    int     `json:"id"`
+		Total *Money  `json:"total"`
+		Lines []Line  `json:"lines"`
+		Notes [2]bool `json:"notes"`
+	}
+	mapper := &set.Mapper{Tags: []string{"json"}, Join: "_", IndexSlices: true}
+	tests := []struct {
+		Key   string
+		Path  string
+		Value interface{}
+		Type  reflect.Type
+	}{
+		{"id", "ID", "abc", reflect.TypeOf(0)},
+		{"total_Amount", "Total.Amount", "abc", reflect.TypeOf(0.0)},
+		{"lines_3_Qty", "Lines[3].Qty", "many", reflect.TypeOf(0)},
+		{"notes_1", "Notes[1]", "maybe", reflect.TypeOf(false)},
+	}
+	keys := []string{}
+	for _, test := range tests {
+		keys = append(keys, test.Key)
+	}
+	chk := assert.New(t)
+	var order Order
+	p, err := mapper.Prepare(&order)
+	chk.NoError(err)
+	chk.NoError(p.Plan(keys...))
+	for _, test := range tests {
+		err = p.Set(test.Value)
+		chk.ErrorIs(err, coerce.ErrInvalid)
+		var fieldErr *set.FieldError
+		if chk.True(errors.As(err, &fieldErr)) {
+			chk.Equal(test.Key, fieldErr.Key)
+			chk.Equal(test.Path, fieldErr.Path)
+			chk.Equal(test.Value, fieldErr.Value)
+			chk.Equal(test.Type, fieldErr.Type)
+		}
+		chk.Contains(err.Error(), "PreparedMapping.Set")
+	}
+	//
+	// The keys belong to the copy and are not changed by a later Plan.
+	copied := p.Copy()
+	chk.NoError(p.Plan("id"))
+	copied.Rebind(&order)
+	var fieldErr *set.FieldError
+	chk.True(errors.As(copied.Set("abc"), &fieldErr))
+	chk.Equal("id", fieldErr.Key)
+	chk.True(errors.As(copied.Set("abc"), &fieldErr))
+	chk.Equal("total_Amount", fieldErr.Key)
+}
+
 func TestPreparedMapping_Field(t *testing.T) {
 	type S struct {
 		A string
@@ -653,6 +712,41 @@ func TestPreparedMapping_PlanCorrectlySetsValid(t *testing.T) {
 	chk.Len(slice, 3)
 }
 
+func TestPreparedMapping_PlanKnown(t *testing.T) {
+	chk := assert.New(t)
+	type S struct {
+		A       int
+		B       string
+		UserID  int
+		User_ID int
+	}
+	var s S
+	mapper := &set.Mapper{Normalize: set.NormalizeKey}
+	p, err := mapper.Prepare(&s)
+	chk.NoError(err)
+	//
+	known, unknown, err := p.PlanKnown("x", "B", "y", "a")
+	chk.NoError(err)
+	chk.Equal([]int{1, 3}, known)
+	chk.Equal([]int{0, 2}, unknown)
+	chk.NoError(p.Set("hello"))
+	chk.NoError(p.Set(42))
+	chk.Equal(S{A: 42, B: "hello"}, s)
+	//
+	fields := []string{"A", "x", "user id", "B"}
+	known, unknown, err = p.PlanKnown(fields...)
+	chk.ErrorIs(err, set.ErrAmbiguousField)
+	chk.Equal([]int{0}, known)
+	chk.Equal([]int{1}, unknown)
+	chk.Equal("user id", fields[len(known)+len(unknown)])
+	chk.ErrorIs(p.Err(), set.ErrNoPlan)
+	//
+	known, unknown, err = p.PlanKnown("x")
+	chk.NoError(err)
+	chk.Nil(known)
+	chk.Equal([]int{0}, unknown)
+}
+
 func TestPreparedMapping_ErrNoPlan(t *testing.T) {
 	// Mapper.Prepare sets the err field in PreparedMapping to ErrNoPlan.
 	// This test covers code blocks in PreparedMapping methods that check for
@@ -675,3 +769,63 @@ func TestPreparedMapping_ErrNoPlan(t *testing.T) {
 	chk.Nil(slice)
 	chk.ErrorIs(err, set.ErrNoPlan)
 }
+
+func TestPreparedMapping_ReadFields(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type Person struct {
+		Name    string
+		Address *Address
+		Scores  [2]int
+	}
+	mapper := &set.Mapper{Join: "_"}
+	t.Run("prepare read only", func(t *testing.T) {
+		chk := assert.New(t)
+		p, err := mapper.PrepareReadOnly(Person{Name: "Alice"})
+		chk.NoError(err)
+		_, err = p.ReadFields(nil)
+		chk.ErrorIs(err, set.ErrNoPlan)
+		chk.ErrorIs(p.Plan("Unknown"), set.ErrUnknownField)
+		_, err = p.ReadFields(nil)
+		chk.ErrorIs(err, set.ErrNoPlan)
+		//
+		chk.NoError(p.Plan("Name", "Address_City", "Scores_1"))
+		values, err := p.ReadFields(nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"Alice", nil, 0}, values)
+		//
+		p.Rebind(Person{Name: "Bob", Address: &Address{City: "Boston"}, Scores: [2]int{1, 2}})
+		values, err = p.Copy().ReadFields(values)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"Bob", "Boston", 2}, values)
+		//
+		// Mutating methods return ErrReadOnly.
+		chk.ErrorIs(p.Set("Carol"), set.ErrReadOnly)
+		_, err = p.Field()
+		chk.ErrorIs(err, set.ErrReadOnly)
+		_, err = p.Fields(nil)
+		chk.ErrorIs(err, set.ErrReadOnly)
+		_, err = p.Assignables(nil)
+		chk.ErrorIs(err, set.ErrReadOnly)
+		chk.ErrorIs(p.Err(), set.ErrReadOnly)
+	})
+	t.Run("prepare", func(t *testing.T) {
+		chk := assert.New(t)
+		var person Person
+		p, err := mapper.Prepare(&person)
+		chk.NoError(err)
+		_, err = p.ReadFields(nil)
+		chk.ErrorIs(err, set.ErrNoPlan)
+		chk.NoError(p.Plan("Name", "Address_City"))
+		values, err := p.ReadFields(nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"", nil}, values)
+		chk.Nil(person.Address)
+		//
+		p, err = mapper.Prepare(person)
+		chk.ErrorIs(err, set.ErrReadOnly)
+		_, err = p.ReadFields(nil)
+		chk.ErrorIs(err, set.ErrReadOnly)
+	})
+}
@@ -91,3 +91,16 @@ func (s *SliceValue) Append(elem reflect.Value) {
 func (s SliceValue) Elem() reflect.Value {
 	return reflect.New(s.ElemType)
 }
+
+// ElemEnd returns a newly allocated slice element as Elem does and a pointer to the value at
+// the end of its pointer chain; the pointers in the chain are allocated.
+//
+// If the slice is []T then elem and end are the same *T.  If the slice is []**T then elem is a
+// ***T and end is the *T at the end of the chain.  Pass elem to Append after populating end.
+func (s SliceValue) ElemEnd() (elem reflect.Value, end reflect.Value) {
+	elem = reflect.New(s.ElemType)
+	for end = elem; end.Elem().Kind() == reflect.Ptr; end = end.Elem() {
+		end.Elem().Set(reflect.New(end.Elem().Type().Elem()))
+	}
+	return elem, end
+}
@@ -57,6 +57,32 @@ func ExampleSlice() {
 	// [42 0 1 3]
 }
 
+func ExampleSliceValue_ElemEnd() {
+	type T struct {
+		N int
+	}
+	var values []**T
+
+	slice, err := set.Slice(&values)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, n := range []int{1, 2} {
+		elem, end := slice.ElemEnd()
+		end.Elem().Field(0).SetInt(int64(n))
+		slice.Append(elem)
+	}
+
+	for _, v := range values {
+		fmt.Println((**v).N)
+	}
+
+	// Output: 1
+	// 2
+}
+
 func ExampleSlice_errors() {
 	var n int
 	var numspp **[]int
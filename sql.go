@@ -0,0 +1,152 @@
+package set
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/nofeaturesonlybugs/set/coerce"
+)
+
+var (
+	// typeScanner and typeValuer are the reflect.Type for sql.Scanner and driver.Valuer.
+	typeScanner = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	typeValuer  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// fieldScanner is an sql.Scanner returned by Assignables when the Mapper has SQLAdapters enabled.
+//
+// Scanned values are assigned into the field with the same rules as Value.To.  NULL sets the
+// field to nil if it is a pointer or the zero value otherwise.  If the field already implements
+// sql.Scanner then scanning is delegated to the field.
+type fieldScanner struct {
+	// field is the struct field; it may be a pointer or pointer chain.
+	field      reflect.Value
+	converters *ConverterRegistry
+}
+
+// newFieldScanner returns a *fieldScanner for field; if prev is already a *fieldScanner it is
+// reused instead of allocating a new one.
+func newFieldScanner(prev interface{}, field reflect.Value, converters *ConverterRegistry) *fieldScanner {
+	s, ok := prev.(*fieldScanner)
+	if !ok {
+		s = &fieldScanner{}
+	}
+	s.field, s.converters = field, converters
+	return s
+}
+
+// Scan implements sql.Scanner.
+func (s *fieldScanner) Scan(src interface{}) error {
+	T := s.field.Type()
+	if T.Kind() != reflect.Ptr && reflect.PtrTo(T).Implements(typeScanner) {
+		return s.field.Addr().Interface().(sql.Scanner).Scan(src)
+	} else if src == nil {
+		s.field.Set(reflect.Zero(T))
+		return nil
+	}
+	//
+	v, _ := Writable(s.field)
+	if reflect.PtrTo(v.Type()).Implements(typeScanner) {
+		return v.Addr().Interface().(sql.Scanner).Scan(src)
+	}
+	//
+	// Drivers may reuse the memory backing []byte after Scan returns so it must be copied.  Types
+	// such as net.IP are []byte but expect their textual representation.  Byte arrays such as
+	// [16]byte hashes are copied into directly.
+	isBytes := (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() == reflect.Uint8 &&
+		!reflect.PtrTo(v.Type()).Implements(typeTextUnmarshaler)
+	switch sw := src.(type) {
+	case []byte:
+		if isBytes && v.Kind() == reflect.Array {
+			return scanByteArray(v, sw)
+		} else if isBytes {
+			v.SetBytes(append([]byte(nil), sw...))
+			return nil
+		}
+		src = string(sw)
+	case string:
+		if isBytes && v.Kind() == reflect.Array {
+			return scanByteArray(v, []byte(sw))
+		} else if isBytes {
+			v.SetBytes([]byte(sw))
+			return nil
+		}
+	}
+	return V(v).to(src, s.converters, false)
+}
+
+// scanByteArray copies b into the byte array v; remaining elements are zeroed.  An error wrapping
+// ErrIndexOutOfBounds is returned if b is longer than v.
+func scanByteArray(v reflect.Value, b []byte) error {
+	if len(b) > v.Len() {
+		return pkgerr{Err: ErrIndexOutOfBounds, CallSite: "fieldScanner.Scan", Context: fmt.Sprintf("%v bytes exceed %v", len(b), v.Type())}
+	}
+	v.Set(reflect.Zero(v.Type()))
+	for n, c := range b {
+		v.Index(n).SetUint(uint64(c))
+	}
+	return nil
+}
+
+// driverValue returns the value in v as a driver.Value.
+//
+// Nil pointers return nil, types implementing driver.Valuer return the result of their Value method,
+// and primitives are converted to one of the types in the driver.Value documentation; byte arrays are
+// converted to []byte.  Types implementing
+// encoding.TextMarshaler or fmt.Stringer are converted to string.  All other types return ErrUnsupported.
+func driverValue(v reflect.Value) (driver.Value, error) {
+	for K := v.Kind(); ; K = v.Kind() {
+		if (K == reflect.Ptr || K == reflect.Interface) && v.IsNil() {
+			return nil, nil
+		} else if v.Type().Implements(typeValuer) {
+			return v.Interface().(driver.Valuer).Value()
+		} else if v.CanAddr() && reflect.PtrTo(v.Type()).Implements(typeValuer) {
+			return v.Addr().Interface().(driver.Valuer).Value()
+		} else if K != reflect.Ptr && K != reflect.Interface {
+			break
+		}
+		v = v.Elem()
+	}
+	//
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return coerce.Int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && !v.Type().Implements(typeTextMarshaler) {
+			return v.Bytes(), nil
+		}
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 && !v.Type().Implements(typeTextMarshaler) {
+			rv := make([]byte, v.Len())
+			for n := range rv {
+				rv[n] = byte(v.Index(n).Uint())
+			}
+			return rv, nil
+		}
+	}
+	//
+	I := v.Interface()
+	if t, ok := I.(time.Time); ok {
+		return t, nil
+	} else if v.CanAddr() {
+		// A pointer has the methods with value and pointer receivers.
+		I = v.Addr().Interface()
+	}
+	switch I.(type) {
+	case encoding.TextMarshaler, fmt.Stringer:
+		return coerce.String(I)
+	}
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,207 @@
+package set_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestMapper_SQLAdapters(t *testing.T) {
+	type Address struct {
+		Street string
+		City   *string
+	}
+	type Row struct {
+		Id      int
+		Name    string
+		Nick    *string
+		Score   float64
+		Active  bool
+		Blob    []byte
+		Created time.Time
+		Deleted *time.Time
+		Level   Level
+		IP      net.IP
+		Null    sql.NullString
+		PNull   *sql.NullInt64
+		Address *Address
+	}
+	mapper := &set.Mapper{
+		TreatAsScalar: set.NewTypeList(sql.NullString{}, sql.NullInt64{}, []byte(nil), net.IP{}, map[string]int{}, big.Int{}),
+		Join:          "_",
+		SQLAdapters:   true,
+	}
+	columns := []string{"Id", "Name", "Nick", "Score", "Active", "Blob", "Created", "Deleted", "Level", "IP", "Null", "PNull", "Address_Street", "Address_City"}
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	//
+	scan := func(chk *assert.Assertions, dest []interface{}, values ...interface{}) {
+		chk.Equal(len(values), len(dest))
+		for k, value := range values {
+			scanner, ok := dest[k].(sql.Scanner)
+			chk.True(ok)
+			chk.NoError(scanner.Scan(value), columns[k])
+		}
+	}
+	t.Run("assignables", func(t *testing.T) {
+		chk := assert.New(t)
+		nick := "bobby"
+		row := Row{Nick: &nick, Deleted: &now, PNull: &sql.NullInt64{}}
+		//
+		b, err := mapper.Bind(&row)
+		chk.NoError(err)
+		dest, err := b.Assignables(columns, nil)
+		chk.NoError(err)
+		blob := []byte("blob")
+		scan(chk, dest, int64(42), []byte("Bob"), nil, "3.5", int64(1), blob, now, nil, []byte("warn"), "10.0.0.1", nil, int64(7), "Main St", []byte("Springfield"))
+		blob[0] = 'X' // Drivers can reuse memory.
+		chk.Equal(42, row.Id)
+		chk.Equal("Bob", row.Name)
+		chk.Nil(row.Nick)
+		chk.Equal(3.5, row.Score)
+		chk.Equal(true, row.Active)
+		chk.Equal([]byte("blob"), row.Blob)
+		chk.Equal(now, row.Created)
+		chk.Nil(row.Deleted)
+		chk.Equal(Level(2), row.Level)
+		chk.Equal("10.0.0.1", row.IP.String())
+		chk.Equal(sql.NullString{}, row.Null)
+		chk.Equal(&sql.NullInt64{Int64: 7, Valid: true}, row.PNull)
+		chk.Equal("Main St", row.Address.Street)
+		chk.Equal("Springfield", *row.Address.City)
+		//
+		// Scanners are reused when the slice is passed back in.
+		var next Row
+		b.Rebind(&next)
+		again, err := b.Assignables(columns, dest)
+		chk.NoError(err)
+		chk.Same(dest[0], again[0])
+		scan(chk, again, nil, nil, "nick", nil, nil, "blob", nil, now, nil, nil, "str", nil, nil, nil)
+		chk.Equal(0, next.Id)
+		chk.Equal("", next.Name)
+		chk.Equal("nick", *next.Nick)
+		chk.Equal([]byte("blob"), next.Blob)
+		chk.Equal(now, *next.Deleted)
+		chk.Equal(sql.NullString{String: "str", Valid: true}, next.Null)
+		chk.Nil(next.PNull)
+		chk.Nil(next.Address.City)
+		//
+		// Coercion errors are returned from Scan.
+		dest, err = b.Assignables([]string{"Id"}, nil)
+		chk.NoError(err)
+		chk.Error(dest[0].(sql.Scanner).Scan("abc"))
+	})
+	t.Run("prepared assignables", func(t *testing.T) {
+		chk := assert.New(t)
+		var row Row
+		p, err := mapper.Prepare(&row)
+		chk.NoError(err)
+		chk.NoError(p.Plan(columns...))
+		dest, err := p.Assignables(nil)
+		chk.NoError(err)
+		scan(chk, dest, "42", "Bob", "nick", 3.5, true, nil, now, now, "info", nil, "null", nil, nil, "City")
+		chk.Equal(42, row.Id)
+		chk.Equal("Bob", row.Name)
+		chk.Equal("nick", *row.Nick)
+		chk.Equal(Level(1), row.Level)
+		chk.Equal(sql.NullString{String: "null", Valid: true}, row.Null)
+		chk.Equal("City", *row.Address.City)
+	})
+	t.Run("fields", func(t *testing.T) {
+		chk := assert.New(t)
+		nick := "bobby"
+		row := Row{
+			Id:      42,
+			Name:    "Bob",
+			Nick:    &nick,
+			Score:   3.5,
+			Active:  true,
+			Blob:    []byte("blob"),
+			Created: now,
+			Level:   1,
+			IP:      net.IPv4(10, 0, 0, 1),
+			Null:    sql.NullString{String: "str", Valid: true},
+			Address: &Address{Street: "Main St"},
+		}
+		expect := []interface{}{int64(42), "Bob", "bobby", 3.5, true, []byte("blob"), now, nil, int64(1), "10.0.0.1", "str", nil, "Main St", nil}
+		//
+		b, err := mapper.Bind(&row)
+		chk.NoError(err)
+		values, err := b.Fields(columns, nil)
+		chk.NoError(err)
+		chk.Equal(expect, values)
+		for _, value := range values {
+			chk.True(value == nil || driver.IsValue(value))
+		}
+		//
+		p, err := mapper.Prepare(&row)
+		chk.NoError(err)
+		chk.NoError(p.Plan(columns...))
+		values, err = p.Fields(nil)
+		chk.NoError(err)
+		chk.Equal(expect, values)
+	})
+	t.Run("fields errors", func(t *testing.T) {
+		type T struct {
+			Big big.Int
+			U   uint64
+			Map map[string]int
+			Ptr **int8
+		}
+		chk := assert.New(t)
+		var v T
+		v.Big.SetInt64(99)
+		v.U = math.MaxUint64
+		i8 := int8(3)
+		pi8 := &i8
+		v.Ptr = &pi8
+		b, err := mapper.Bind(&v)
+		chk.NoError(err)
+		values, err := b.Fields([]string{"Big", "Ptr"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"99", int64(3)}, values)
+		_, err = b.Fields([]string{"U"}, nil)
+		chk.Error(err)
+		_, err = b.Fields([]string{"Map"}, nil)
+		chk.ErrorIs(err, set.ErrUnsupported)
+		//
+		p, err := mapper.Prepare(&v)
+		chk.NoError(err)
+		chk.NoError(p.Plan("Map"))
+		_, err = p.Fields(nil)
+		chk.ErrorIs(err, set.ErrUnsupported)
+	})
+	t.Run("byte arrays", func(t *testing.T) {
+		type T struct {
+			Hash  [4]byte
+			PHash *[4]byte
+		}
+		chk := assert.New(t)
+		chk.Equal([]string{"Hash", "PHash"}, mapper.Map(T{}).Keys)
+		v := T{Hash: [4]byte{1, 2, 3, 4}}
+		b, err := mapper.Bind(&v)
+		chk.NoError(err)
+		values, err := b.Fields([]string{"Hash", "PHash"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{[]byte{1, 2, 3, 4}, nil}, values)
+		//
+		dest, err := b.Assignables([]string{"Hash", "PHash"}, nil)
+		chk.NoError(err)
+		src := []byte{5, 6, 7}
+		chk.NoError(dest[0].(sql.Scanner).Scan(src))
+		src[0] = 'X' // Drivers can reuse memory.
+		chk.NoError(dest[1].(sql.Scanner).Scan("abcd"))
+		chk.Equal([4]byte{5, 6, 7, 0}, v.Hash)
+		chk.Equal(&[4]byte{'a', 'b', 'c', 'd'}, v.PHash)
+		chk.ErrorIs(dest[0].(sql.Scanner).Scan([]byte("too long")), set.ErrIndexOutOfBounds)
+		chk.NoError(dest[1].(sql.Scanner).Scan(nil))
+		chk.Nil(v.PHash)
+	})
+}
@@ -0,0 +1,87 @@
+package sqlscan_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeTable is the result of a query to fakeDriver; err is returned after the rows.
+type fakeTable struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+// fakeTables contains the results returned by fakeDriver; the query is the table name.
+var fakeTables = map[string]fakeTable{}
+
+func init() {
+	sql.Register("sqlscan_fake", fakeDriver{})
+}
+
+// fakeDriver is a read-only database/sql driver serving fakeTables.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake: transactions not supported")
+}
+
+type fakeStmt struct {
+	query string
+}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return 0 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("fake: exec not supported")
+}
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	table, ok := fakeTables[s.query]
+	if !ok {
+		return nil, errors.New("fake: unknown table " + s.query)
+	}
+	return &fakeRows{table: table}, nil
+}
+
+type fakeRows struct {
+	table fakeTable
+	n     int
+}
+
+func (r *fakeRows) Columns() []string { return r.table.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.n == len(r.table.rows) {
+		if r.table.err != nil {
+			return r.table.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.table.rows[r.n])
+	r.n++
+	return nil
+}
+
+// query returns the rows for table.
+func query(t *testing.T, table string) *sql.Rows {
+	t.Helper()
+	db, err := sql.Open("sqlscan_fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
@@ -0,0 +1,16 @@
+package sqlscan
+
+import (
+	"errors"
+)
+
+// The following errors can be checked with errors.Is.
+var (
+	// ErrUnmappedColumn occurs when a column does not match any field and the Scanner's
+	// UnmappedColumns is UnmappedColumnError.
+	ErrUnmappedColumn = errors.New("sqlscan: unmapped column")
+
+	// ErrUnsupportedType occurs when the destination is not a pointer to a struct or a pointer
+	// to a slice of structs.
+	ErrUnsupportedType = errors.New("sqlscan: unsupported type")
+)
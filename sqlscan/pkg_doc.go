@@ -0,0 +1,27 @@
+// Package sqlscan scans *sql.Rows into structs using a set.Mapper and set.PreparedMapping.
+//
+// Columns
+//
+// Each column name returned by Rows.Columns is a key generated by the Mapper.  The
+// DefaultMapper uses struct tags named db followed by json, joins nested names with "_", and
+// enables SQLAdapters so NULL can be scanned into fields that are not pointers:
+//	type Person struct {
+//		ID      int    `db:"id"`
+//		Name    string `db:"name"`
+//		Address struct {
+//			City string `db:"city"`
+//		} `db:"address"`
+//	}
+//	// Columns: id, name, address_city
+//
+// Columns that do not match a field are an error unless the Scanner's UnmappedColumns is
+// UnmappedColumnDiscard.
+//
+// Scanning
+//
+// All appends every row to a slice, One scans the first row into a struct, and Each scans
+// every row into the same struct and calls a function after each row.  The access plan and
+// the arguments to Rows.Scan are created once per call and reused for every row.
+//
+// Every function closes the rows before returning.
+package sqlscan
@@ -0,0 +1,213 @@
+package sqlscan
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+// DefaultMapper is the Mapper used by a Scanner when its Mapper is nil.
+//
+// Struct tags named db and then json override field names, nested names are joined with "_",
+// and SQLAdapters is enabled.
+var DefaultMapper = &set.Mapper{
+	Tags:        []string{"db", "json"},
+	Join:        "_",
+	SQLAdapters: true,
+}
+
+// UnmappedColumnPolicy controls how a Scanner handles columns that do not match a field.
+type UnmappedColumnPolicy int
+
+const (
+	// UnmappedColumnError causes scanning to return an error wrapping ErrUnmappedColumn.
+	UnmappedColumnError UnmappedColumnPolicy = iota
+
+	// UnmappedColumnDiscard causes the values in unmapped columns to be scanned and discarded.
+	UnmappedColumnDiscard
+)
+
+// Scanner scans rows into structs.  The zero value is ready to use.
+type Scanner struct {
+	// Mapper creates the keys matched against the column names; if nil then DefaultMapper is
+	// used.
+	Mapper *set.Mapper
+
+	// UnmappedColumns is the policy for columns that do not match a field.
+	UnmappedColumns UnmappedColumnPolicy
+}
+
+// All scans rows into dst with a zero Scanner; see Scanner.All.
+func All(rows *sql.Rows, dst interface{}) error {
+	return Scanner{}.All(rows, dst)
+}
+
+// One scans rows into dst with a zero Scanner; see Scanner.One.
+func One(rows *sql.Rows, dst interface{}) error {
+	return Scanner{}.One(rows, dst)
+}
+
+// Each scans rows into dst with a zero Scanner; see Scanner.Each.
+func Each(rows *sql.Rows, dst interface{}, fn func() error) error {
+	return Scanner{}.Each(rows, dst, fn)
+}
+
+// All appends every row to dst, which must be a pointer to a slice of structs or pointers to
+// structs.
+//
+// The rows scanned before an error are appended to dst.
+func (s Scanner) All(rows *sql.Rows, dst interface{}) error {
+	defer rows.Close()
+	slice, err := set.Slice(dst)
+	if err != nil {
+		return err
+	} else if slice.ElemEndType.Kind() != reflect.Struct {
+		return fmt.Errorf("%w; expected slice of struct but got %v", ErrUnsupportedType, slice.V.Type())
+	}
+	//
+	var scanner *rowScanner
+	for rows.Next() {
+		elem, target := slice.ElemEnd()
+		if scanner == nil {
+			if scanner, err = s.prepare(rows, target); err != nil {
+				return err
+			}
+		}
+		if err = scanner.scan(rows, target); err != nil {
+			return err
+		}
+		slice.Append(elem)
+	}
+	return rows.Err()
+}
+
+// One scans the first row into dst, which must be a pointer to a struct; any remaining rows
+// are discarded.  If there are no rows then sql.ErrNoRows is returned.
+func (s Scanner) One(rows *sql.Rows, dst interface{}) error {
+	defer rows.Close()
+	v, err := structPointer(dst)
+	if err != nil {
+		return err
+	} else if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	scanner, err := s.prepare(rows, v)
+	if err != nil {
+		return err
+	} else if err = scanner.scan(rows, v); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// Each scans every row into dst, which must be a pointer to a struct, and calls fn after each
+// row.  Scanning stops if fn returns an error and the error is returned.
+//
+// dst is reused for every row; fields are not reset between rows so fn must copy any values it
+// keeps.
+func (s Scanner) Each(rows *sql.Rows, dst interface{}, fn func() error) error {
+	defer rows.Close()
+	v, err := structPointer(dst)
+	if err != nil {
+		return err
+	}
+	var scanner *rowScanner
+	for rows.Next() {
+		if scanner == nil {
+			if scanner, err = s.prepare(rows, v); err != nil {
+				return err
+			}
+		}
+		if err = scanner.scan(rows, v); err != nil {
+			return err
+		} else if err = fn(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// prepare creates a *rowScanner for the columns in rows and v, which is a pointer to a struct.
+func (s Scanner) prepare(rows *sql.Rows, v reflect.Value) (*rowScanner, error) {
+	mapper := s.Mapper
+	if mapper == nil {
+		mapper = DefaultMapper
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	prepared, err := mapper.Prepare(v)
+	if err != nil {
+		return nil, err
+	}
+	known, unmapped, err := prepared.PlanKnown(columns...)
+	if err != nil {
+		return nil, err
+	}
+	rv := &rowScanner{
+		prepared:    prepared,
+		dest:        make([]interface{}, len(columns)),
+		assignables: make([]interface{}, len(known)),
+		columns:     known,
+	}
+	// Unmapped columns are scanned into discard so Rows.Scan receives a destination for every
+	// column.
+	names := make([]string, len(unmapped))
+	for k, column := range unmapped {
+		names[k], rv.dest[column] = columns[column], discard{}
+	}
+	if len(unmapped) > 0 && s.UnmappedColumns == UnmappedColumnError {
+		return nil, fmt.Errorf("%w [%v] for %v", ErrUnmappedColumn, strings.Join(names, ", "), v.Type().Elem())
+	}
+	return rv, nil
+}
+
+// rowScanner scans rows into structs of a single type.
+type rowScanner struct {
+	prepared set.PreparedMapping
+
+	// dest contains the arguments to Rows.Scan and assignables is the buffer passed to
+	// PreparedMapping.Assignables; columns contains the index in dest of each planned column.
+	dest        []interface{}
+	assignables []interface{}
+	columns     []int
+}
+
+// scan scans the current row into v, which must have the type given to prepare.
+func (r *rowScanner) scan(rows *sql.Rows, v reflect.Value) error {
+	r.prepared.Rebind(v)
+	assignables, err := r.prepared.Assignables(r.assignables)
+	if err != nil {
+		return err
+	}
+	for k, column := range r.columns {
+		r.dest[column] = assignables[k]
+	}
+	return rows.Scan(r.dest...)
+}
+
+// structPointer returns dst as a reflect.Value if it is a non-nil pointer to a struct.
+func structPointer(dst interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Type().Elem().Kind() != reflect.Struct {
+		return v, fmt.Errorf("%w; expected pointer to struct but got %T", ErrUnsupportedType, dst)
+	} else if v.IsNil() {
+		return v, fmt.Errorf("%w; nil %T", ErrUnsupportedType, dst)
+	}
+	return v, nil
+}
+
+// discard is an sql.Scanner that discards the values of unmapped columns.
+type discard struct{}
+
+// Scan implements sql.Scanner.
+func (discard) Scan(src interface{}) error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package sqlscan_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/nofeaturesonlybugs/set/sqlscan"
+)
+
+func ExampleScanner_All() {
+	fakeTables["SELECT id, name, nickname FROM users"] = fakeTable{
+		columns: []string{"id", "name", "nickname"},
+		rows: [][]driver.Value{
+			{int64(1), "Alice", "Al"},
+			{int64(2), "Bob", nil},
+		},
+	}
+	type User struct {
+		ID       int    `db:"id"`
+		Name     string `db:"name"`
+		Nickname string `db:"nickname"`
+	}
+	db, err := sql.Open("sqlscan_fake", "")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT id, name, nickname FROM users")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	var users []User
+	if err = (sqlscan.Scanner{}).All(rows, &users); err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, user := range users {
+		fmt.Printf("%v %v %q\n", user.ID, user.Name, user.Nickname)
+	}
+
+	// Output: 1 Alice "Al"
+	// 2 Bob ""
+}
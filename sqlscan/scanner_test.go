@@ -0,0 +1,151 @@
+package sqlscan_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/sqlscan"
+)
+
+type Address struct {
+	City string `db:"city"`
+}
+
+type Person struct {
+	ID      int       `db:"id"`
+	Name    string    `db:"name"`
+	Email   *string   `db:"email"`
+	Created time.Time `db:"created"`
+	Address Address   `db:"address"`
+}
+
+var created = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func init() {
+	fakeTables["people"] = fakeTable{
+		columns: []string{"id", "name", "email", "created", "address_city"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Alice"), "alice@example.com", created, "Boston"},
+			{int64(2), "Bob", nil, created, nil},
+		},
+	}
+	fakeTables["people_extra"] = fakeTable{
+		columns: []string{"id", "age", "name", "rank"},
+		rows: [][]driver.Value{
+			{int64(1), int64(30), "Alice", int64(1)},
+		},
+	}
+	fakeTables["empty"] = fakeTable{
+		columns: []string{"id", "name"},
+	}
+	fakeTables["broken"] = fakeTable{
+		columns: []string{"id", "name"},
+		rows: [][]driver.Value{
+			{int64(1), "Alice"},
+		},
+		err: errors.New("connection lost"),
+	}
+	fakeTables["users"] = fakeTable{
+		columns: []string{"user_id", "USER_NAME"},
+		rows: [][]driver.Value{
+			{int64(7), nil},
+		},
+	}
+	fakeTables["bad_id"] = fakeTable{
+		columns: []string{"id"},
+		rows: [][]driver.Value{
+			{"abc"},
+		},
+	}
+}
+
+func TestScanner(t *testing.T) {
+	email := "alice@example.com"
+	alice := Person{ID: 1, Name: "Alice", Email: &email, Created: created, Address: Address{City: "Boston"}}
+	bob := Person{ID: 2, Name: "Bob", Created: created}
+	t.Run("all", func(t *testing.T) {
+		chk := assert.New(t)
+		var people []Person
+		chk.NoError(sqlscan.All(query(t, "people"), &people))
+		chk.Equal([]Person{alice, bob}, people)
+		//
+		var pointers []*Person
+		chk.NoError(sqlscan.All(query(t, "people"), &pointers))
+		chk.Equal([]*Person{&alice, &bob}, pointers)
+		//
+		var empty []Person
+		chk.NoError(sqlscan.All(query(t, "empty"), &empty))
+		chk.Empty(empty)
+		//
+		var partial []Person
+		chk.EqualError(sqlscan.All(query(t, "broken"), &partial), "connection lost")
+		chk.Equal([]Person{{ID: 1, Name: "Alice"}}, partial)
+	})
+	t.Run("one", func(t *testing.T) {
+		chk := assert.New(t)
+		var p Person
+		chk.NoError(sqlscan.One(query(t, "people"), &p))
+		chk.Equal(alice, p)
+		chk.ErrorIs(sqlscan.One(query(t, "empty"), &p), sql.ErrNoRows)
+	})
+	t.Run("each", func(t *testing.T) {
+		chk := assert.New(t)
+		var p Person
+		var names []string
+		err := sqlscan.Each(query(t, "people"), &p, func() error {
+			names = append(names, p.Name)
+			return nil
+		})
+		chk.NoError(err)
+		chk.Equal([]string{"Alice", "Bob"}, names)
+		chk.Nil(p.Email)
+		//
+		stop := errors.New("stop")
+		calls := 0
+		err = sqlscan.Each(query(t, "people"), &p, func() error {
+			calls++
+			return stop
+		})
+		chk.ErrorIs(err, stop)
+		chk.Equal(1, calls)
+	})
+	t.Run("unmapped columns", func(t *testing.T) {
+		chk := assert.New(t)
+		var people []Person
+		err := sqlscan.All(query(t, "people_extra"), &people)
+		chk.ErrorIs(err, sqlscan.ErrUnmappedColumn)
+		chk.EqualError(err, "sqlscan: unmapped column [age, rank] for sqlscan_test.Person")
+		//
+		scanner := sqlscan.Scanner{UnmappedColumns: sqlscan.UnmappedColumnDiscard}
+		chk.NoError(scanner.All(query(t, "people_extra"), &people))
+		chk.Equal([]Person{{ID: 1, Name: "Alice"}}, people)
+	})
+	t.Run("mapper", func(t *testing.T) {
+		chk := assert.New(t)
+		type T struct {
+			UserID   int
+			UserName string
+		}
+		scanner := sqlscan.Scanner{Mapper: &set.Mapper{Normalize: set.NormalizeKey, SQLAdapters: true}}
+		var v T
+		chk.NoError(scanner.One(query(t, "users"), &v))
+		chk.Equal(T{UserID: 7}, v)
+	})
+	t.Run("errors", func(t *testing.T) {
+		chk := assert.New(t)
+		var p Person
+		chk.Error(sqlscan.One(query(t, "bad_id"), &p))
+		chk.ErrorIs(sqlscan.One(query(t, "people"), p), sqlscan.ErrUnsupportedType)
+		chk.ErrorIs(sqlscan.One(query(t, "people"), (*Person)(nil)), sqlscan.ErrUnsupportedType)
+		chk.ErrorIs(sqlscan.Each(query(t, "people"), []Person{}, nil), sqlscan.ErrUnsupportedType)
+		var ints []int
+		chk.ErrorIs(sqlscan.All(query(t, "people"), &ints), sqlscan.ErrUnsupportedType)
+		chk.ErrorIs(sqlscan.All(query(t, "people"), []Person{}), set.ErrInvalidSlice)
+	})
+}
@@ -0,0 +1,86 @@
+package set
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TagOptions are the comma separated options following the name in a struct tag used by a Mapper.
+//
+//	type T struct {
+//		Name string `json:"name,omitempty,alias=full_name|fullname,default=anonymous,required"`
+//	}
+//	// Mapping.Options["name"] is TagOptions{"omitempty", "alias=full_name|fullname", "default=anonymous", "required"}
+//
+// Options are not interpreted by the Mapper; they are provided so code built on top of Mapping
+// can act on them.  Option values can not contain commas.
+type TagOptions []string
+
+// Has returns true if the option name is present with or without a value.
+func (o TagOptions) Has(name string) bool {
+	_, ok := o.Lookup(name)
+	return ok
+}
+
+// Lookup returns the value of the option name written as name=value.  If the option is present
+// without a value then value is the empty string and ok is true.  If the option is not present
+// then ok is false.
+func (o TagOptions) Lookup(name string) (value string, ok bool) {
+	for _, option := range o {
+		if option == name {
+			return "", true
+		} else if strings.HasPrefix(option, name) && len(option) > len(name) && option[len(name)] == '=' {
+			return option[len(name)+1:], true
+		}
+	}
+	return "", false
+}
+
+// Values returns the value of the option name split by "|"; it returns nil if the option is not
+// present or has no value.
+//
+//	TagOptions{"alias=a|b"}.Values("alias") // []string{"a", "b"}
+func (o TagOptions) Values(name string) []string {
+	if value, _ := o.Lookup(name); value != "" {
+		return strings.Split(value, "|")
+	}
+	return nil
+}
+
+// structTag is a struct tag value parsed into its name and options.
+type structTag struct {
+	// found is true if the tag is present on the field.
+	found bool
+
+	// skip is true if the tag value is "-".
+	skip bool
+
+	name    string
+	options TagOptions
+}
+
+// parseStructTag looks up the first of the tags present on field and parses its value.
+//
+// The value is split on commas; the first element is the name and any remaining elements are
+// options.  A value of "-" skips the field; use "-," for a field named "-".
+func parseStructTag(field reflect.StructField, tags []string) structTag {
+	for _, tagName := range tags {
+		value, ok := field.Tag.Lookup(tagName)
+		if !ok {
+			continue
+		} else if value == "-" {
+			return structTag{found: true, skip: true}
+		}
+		rv := structTag{found: true, name: value}
+		if n := strings.Index(value, ","); n != -1 {
+			rv.name = value[:n]
+			for _, option := range strings.Split(value[n+1:], ",") {
+				if option != "" {
+					rv.options = append(rv.options, option)
+				}
+			}
+		}
+		return rv
+	}
+	return structTag{}
+}
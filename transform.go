@@ -0,0 +1,133 @@
+package set
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitWords splits a Go identifier into words.  Runs of upper case letters are kept together
+// as initialisms so that HTTPServerID is split into HTTP, Server, and ID; a trailing s after
+// an initialism is kept with it as in IDs.  Runes that are not letters or digits separate words
+// and are discarded.
+//
+//	SplitWords("HTTPServerID") // []string{"HTTP", "Server", "ID"}
+//	SplitWords("userURLs")     // []string{"user", "URLs"}
+//	SplitWords("Address2_Zip") // []string{"Address2", "Zip"}
+func SplitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := -1
+	for k, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start != -1 {
+				words = append(words, string(runes[start:k]))
+				start = -1
+			}
+			continue
+		} else if start == -1 {
+			start = k
+			continue
+		}
+		prev := runes[k-1]
+		switch {
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			// userName, Vec2D
+		case unicode.IsUpper(r) && unicode.IsUpper(prev) && k+1 < len(runes) && unicode.IsLower(runes[k+1]) && !isPluralInitialism(runes, k+1):
+			// The last upper case letter of HTTPServer begins Server.
+		default:
+			continue
+		}
+		words = append(words, string(runes[start:k]))
+		start = k
+	}
+	if start != -1 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// isPluralInitialism returns true if runes[k] is an s that pluralizes the initialism before it
+// as in IDs or URLs.
+func isPluralInitialism(runes []rune, k int) bool {
+	if runes[k] != 's' || k < 2 || !unicode.IsUpper(runes[k-2]) {
+		return false
+	}
+	return k+1 == len(runes) || !unicode.IsLower(runes[k+1])
+}
+
+// JoinWords returns a function suitable for Mapper.Transform.  The function splits names with
+// SplitWords, passes each word and its position to fn, and joins the results with sep.
+//
+// Use JoinWords to create transforms that are not provided by this package; for example
+// dotted lower case:
+//	mapper := &set.Mapper{
+//		Transform: set.JoinWords(".", func(n int, word string) string { return strings.ToLower(word) }),
+//		Join:      ".",
+//	}
+func JoinWords(sep string, fn func(n int, word string) string) func(string) string {
+	return func(name string) string {
+		words := SplitWords(name)
+		for n, word := range words {
+			words[n] = fn(n, word)
+		}
+		return strings.Join(words, sep)
+	}
+}
+
+// lowerWord and upperWord are arguments for JoinWords.
+func lowerWord(n int, word string) string { return strings.ToLower(word) }
+func upperWord(n int, word string) string { return strings.ToUpper(word) }
+
+var (
+	snakeCase          = JoinWords("_", lowerWord)
+	screamingSnakeCase = JoinWords("_", upperWord)
+	kebabCase          = JoinWords("-", lowerWord)
+	lowerCase          = JoinWords("", lowerWord)
+	camelCase          = JoinWords("", func(n int, word string) string {
+		if n == 0 {
+			return strings.ToLower(word)
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		return string(runes)
+	})
+)
+
+// SnakeCase transforms name to snake_case; combine it with Join "_" for names such as
+// http_server_id.
+//
+//	SnakeCase("HTTPServerID") // http_server_id
+func SnakeCase(name string) string {
+	return snakeCase(name)
+}
+
+// ScreamingSnakeCase transforms name to SCREAMING_SNAKE_CASE; combine it with Join "_" for
+// names suitable as environment variables.
+//
+//	ScreamingSnakeCase("HTTPServerID") // HTTP_SERVER_ID
+func ScreamingSnakeCase(name string) string {
+	return screamingSnakeCase(name)
+}
+
+// KebabCase transforms name to kebab-case; combine it with Join "-".
+//
+//	KebabCase("HTTPServerID") // http-server-id
+func KebabCase(name string) string {
+	return kebabCase(name)
+}
+
+// CamelCase transforms name to camelCase.  The first word is lower case and the remaining words
+// begin with an upper case letter; initialisms after the first word keep their case.
+//
+//	CamelCase("HTTPServerID") // httpServerID
+//	CamelCase("user_name")    // userName
+func CamelCase(name string) string {
+	return camelCase(name)
+}
+
+// LowerCase transforms name to lower case with separators removed.
+//
+//	LowerCase("HTTPServerID") // httpserverid
+func LowerCase(name string) string {
+	return lowerCase(name)
+}
@@ -0,0 +1,76 @@
+package set_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestTransforms(t *testing.T) {
+	type Test struct {
+		Name           string
+		Words          []string
+		Snake          string
+		ScreamingSnake string
+		Kebab          string
+		Camel          string
+		Lower          string
+	}
+	tests := []Test{
+		{"Name", []string{"Name"}, "name", "NAME", "name", "name", "name"},
+		{"ID", []string{"ID"}, "id", "ID", "id", "id", "id"},
+		{"UserID", []string{"User", "ID"}, "user_id", "USER_ID", "user-id", "userID", "userid"},
+		{"HTTPServer", []string{"HTTP", "Server"}, "http_server", "HTTP_SERVER", "http-server", "httpServer", "httpserver"},
+		{"HTTPServerID", []string{"HTTP", "Server", "ID"}, "http_server_id", "HTTP_SERVER_ID", "http-server-id", "httpServerID", "httpserverid"},
+		{"URLPath", []string{"URL", "Path"}, "url_path", "URL_PATH", "url-path", "urlPath", "urlpath"},
+		{"userURLs", []string{"user", "URLs"}, "user_urls", "USER_URLS", "user-urls", "userURLs", "userurls"},
+		{"IDsByName", []string{"IDs", "By", "Name"}, "ids_by_name", "IDS_BY_NAME", "ids-by-name", "idsByName", "idsbyname"},
+		{"IsValid", []string{"Is", "Valid"}, "is_valid", "IS_VALID", "is-valid", "isValid", "isvalid"},
+		{"Address2", []string{"Address2"}, "address2", "ADDRESS2", "address2", "address2", "address2"},
+		{"Vec2D", []string{"Vec2", "D"}, "vec2_d", "VEC2_D", "vec2-d", "vec2D", "vec2d"},
+		{"user_name", []string{"user", "name"}, "user_name", "USER_NAME", "user-name", "userName", "username"},
+		{"__Leading  space-", []string{"Leading", "space"}, "leading_space", "LEADING_SPACE", "leading-space", "leadingSpace", "leadingspace"},
+		{"ÜberName", []string{"Über", "Name"}, "über_name", "ÜBER_NAME", "über-name", "überName", "übername"},
+		{"", nil, "", "", "", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			chk := assert.New(t)
+			chk.Equal(test.Words, set.SplitWords(test.Name))
+			chk.Equal(test.Snake, set.SnakeCase(test.Name))
+			chk.Equal(test.ScreamingSnake, set.ScreamingSnakeCase(test.Name))
+			chk.Equal(test.Kebab, set.KebabCase(test.Name))
+			chk.Equal(test.Camel, set.CamelCase(test.Name))
+			chk.Equal(test.Lower, set.LowerCase(test.Name))
+		})
+	}
+}
+
+func TestTransforms_Mapper(t *testing.T) {
+	type HTTPServer struct {
+		ID      int
+		HostURL string
+	}
+	type Config struct {
+		HTTPServer HTTPServer
+	}
+	chk := assert.New(t)
+	mapper := &set.Mapper{
+		Transform: set.SnakeCase,
+		Join:      "_",
+	}
+	chk.Equal([]string{"http_server_id", "http_server_host_url"}, mapper.Map(Config{}).Keys)
+	mapper = &set.Mapper{
+		Transform: set.KebabCase,
+		Join:      "-",
+	}
+	chk.Equal([]string{"http-server-id", "http-server-host-url"}, mapper.Map(Config{}).Keys)
+	mapper = &set.Mapper{
+		Transform: set.JoinWords(".", func(n int, word string) string { return strings.ToLower(word) }),
+		Join:      ".",
+	}
+	chk.Equal([]string{"http.server.id", "http.server.host.url"}, mapper.Map(Config{}).Keys)
+}
@@ -1,10 +1,18 @@
 package set
 
 import (
+	"encoding"
 	"reflect"
 	"sync"
 )
 
+// typeTextMarshaler and typeTextUnmarshaler are the reflect.Type for encoding.TextMarshaler and
+// encoding.TextUnmarshaler.
+var (
+	typeTextMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	typeTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
 // TypeInfo summarizes information about a type T in a meaningful way for this package.
 type TypeInfo struct {
 	// True if the Value is a scalar type:
@@ -19,6 +27,9 @@ type TypeInfo struct {
 	// True if the Value is a slice.
 	IsSlice bool
 
+	// True if the Value is an array.
+	IsArray bool
+
 	// True if the Value is a struct.
 	IsStruct bool
 
@@ -30,12 +41,15 @@ type TypeInfo struct {
 	// type at the end of the pointer chain.  Otherwise it will be the original type.
 	Type reflect.Type
 
-	// When IsMap or IsSlice are true then ElemType will be the reflect.Type for elements that can be directly
-	// inserted into the map or slice; it is not the type at the end of the chain if the element type is a pointer.
+	// When IsMap, IsSlice, or IsArray are true then ElemType will be the reflect.Type for elements that can be directly
+	// inserted into the map, slice, or array; it is not the type at the end of the chain if the element type is a pointer.
 	ElemType reflect.Type
 
 	// When IsStruct is true then StructFields will contain the reflect.StructField values for the struct.
 	StructFields []reflect.StructField
+
+	// isTextUnmarshaler is true when a pointer to Type implements encoding.TextUnmarshaler.
+	isTextUnmarshaler bool
 }
 
 // TypeInfoCache builds a cache of TypeInfo types; when requesting TypeInfo for a type T that is a pointer
@@ -105,13 +119,14 @@ func (me *typeInfoCache) StatType(T reflect.Type) TypeInfo {
 	//
 	rv.IsMap = K == reflect.Map
 	rv.IsSlice = K == reflect.Slice
+	rv.IsArray = K == reflect.Array
 	rv.IsStruct = K == reflect.Struct
 	rv.IsScalar = K == reflect.Bool ||
 		K == reflect.Int || K == reflect.Int8 || K == reflect.Int16 || K == reflect.Int32 || K == reflect.Int64 ||
 		K == reflect.Uint || K == reflect.Uint8 || K == reflect.Uint16 || K == reflect.Uint32 || K == reflect.Uint64 ||
 		K == reflect.Float32 || K == reflect.Float64 ||
 		K == reflect.String
-	if rv.IsMap || rv.IsSlice {
+	if rv.IsMap || rv.IsSlice || rv.IsArray {
 		rv.ElemType = T.Elem()
 	} else if rv.IsStruct {
 		for k, size := 0, T.NumField(); k < size; k++ {
@@ -119,6 +134,7 @@ func (me *typeInfoCache) StatType(T reflect.Type) TypeInfo {
 		}
 	}
 	rv.Type, rv.Kind = T, K
+	rv.isTextUnmarshaler = reflect.PtrTo(T).Implements(typeTextUnmarshaler)
 	//
 	me.cache.Store(origT, rv)
 	//
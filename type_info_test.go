@@ -11,7 +11,7 @@ import (
 )
 
 func typeinfo_Invalid(i set.TypeInfo) bool {
-	return i.IsMap == false && i.IsScalar == false && i.IsSlice == false && i.IsStruct == false && i.Kind == reflect.Invalid && i.Type == nil && i.ElemType == nil
+	return i.IsMap == false && i.IsScalar == false && i.IsSlice == false && i.IsArray == false && i.IsStruct == false && i.Kind == reflect.Invalid && i.Type == nil && i.ElemType == nil
 }
 
 func TestTypeInfo(t *testing.T) {
@@ -31,6 +31,8 @@ func TestTypeInfo(t *testing.T) {
 		var sl []struct{}
 		var slp *[]struct{}
 		var slpp *[]struct{}
+		var arr [3]int
+		var arrp *[3]int
 		size := 5
 		ch := make(chan struct{})
 		signals := []chan struct{}{}
@@ -85,6 +87,13 @@ func TestTypeInfo(t *testing.T) {
 				info = set.TypeCache.Stat(slpp)
 				chk.Equal(true, info.IsSlice)
 				//
+				info = set.TypeCache.Stat(arr)
+				chk.Equal(true, info.IsArray)
+				chk.Equal(false, info.IsSlice)
+				chk.Equal(reflect.TypeOf(0), info.ElemType)
+				info = set.TypeCache.Stat(arrp)
+				chk.Equal(true, info.IsArray)
+				//
 				close(signals[idx])
 			}(k)
 		}
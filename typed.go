@@ -0,0 +1,91 @@
+package set
+
+// To returns v coerced to T with the same rules as Value.To.
+func To[T any](v any) (T, error) {
+	var rv T
+	err := V(&rv).To(v)
+	return rv, err
+}
+
+// TypedField returns the field at key from b coerced to F with the same rules as Value.To;
+// b is a BoundMapping or TypedBoundMapping.
+//
+// If the field already has type F it is returned without coercion.
+func TypedField[F any](b interface {
+	Field(field string) (Value, error)
+}, key string) (F, error) {
+	var rv F
+	v, err := b.Field(key)
+	if err != nil {
+		return rv, err
+	} else if field, ok := v.TopValue.Interface().(F); ok {
+		return field, nil
+	}
+	return To[F](v.TopValue.Interface())
+}
+
+// TypedBoundMapping is a BoundMapping for values of type *T created with Bind.
+//
+// The embedded BoundMapping provides the remaining methods; calling its Rebind directly
+// bypasses the type safety of TypedBoundMapping.Rebind.
+type TypedBoundMapping[T any] struct {
+	BoundMapping
+}
+
+// Bind creates a TypedBoundMapping bound to v with mapper; when mapper is nil DefaultMapper
+// is used.
+func Bind[T any](mapper *Mapper, v *T) (TypedBoundMapping[T], error) {
+	if mapper == nil {
+		mapper = DefaultMapper
+	}
+	b, err := mapper.Bind(v)
+	return TypedBoundMapping[T]{BoundMapping: b}, err
+}
+
+// Copy creates an exact copy of the TypedBoundMapping.
+func (b TypedBoundMapping[T]) Copy() TypedBoundMapping[T] {
+	return TypedBoundMapping[T]{BoundMapping: b.BoundMapping.Copy()}
+}
+
+// Rebind replaces the currently bound value with v.  Unlike BoundMapping.Rebind the type of v
+// is checked by the compiler; an error wrapping ErrReadOnly is returned if v is nil.
+func (b *TypedBoundMapping[T]) Rebind(v *T) error {
+	if v == nil {
+		return pkgerr{Err: ErrReadOnly, CallSite: "TypedBoundMapping.Rebind", Hint: "v is a nil pointer"}
+	}
+	b.BoundMapping.Rebind(v)
+	return nil
+}
+
+// TypedPreparedMapping is a PreparedMapping for values of type *T created with Prepare.
+//
+// The embedded PreparedMapping provides the remaining methods; calling its Rebind directly
+// bypasses the type safety of TypedPreparedMapping.Rebind.
+type TypedPreparedMapping[T any] struct {
+	PreparedMapping
+}
+
+// Prepare creates a TypedPreparedMapping bound to v with mapper; when mapper is nil
+// DefaultMapper is used.
+func Prepare[T any](mapper *Mapper, v *T) (TypedPreparedMapping[T], error) {
+	if mapper == nil {
+		mapper = DefaultMapper
+	}
+	p, err := mapper.Prepare(v)
+	return TypedPreparedMapping[T]{PreparedMapping: p}, err
+}
+
+// Copy creates an exact copy of the TypedPreparedMapping.
+func (p TypedPreparedMapping[T]) Copy() TypedPreparedMapping[T] {
+	return TypedPreparedMapping[T]{PreparedMapping: p.PreparedMapping.Copy()}
+}
+
+// Rebind replaces the currently bound value with v.  Unlike PreparedMapping.Rebind the type
+// of v is checked by the compiler; an error wrapping ErrReadOnly is returned if v is nil.
+func (p *TypedPreparedMapping[T]) Rebind(v *T) error {
+	if v == nil {
+		return pkgerr{Err: ErrReadOnly, CallSite: "TypedPreparedMapping.Rebind", Hint: "v is a nil pointer"}
+	}
+	p.PreparedMapping.Rebind(v)
+	return nil
+}
@@ -0,0 +1,43 @@
+package set_test
+
+import (
+	"fmt"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func ExampleTo() {
+	n, err := set.To[int]("42")
+	fmt.Println(n, err)
+
+	b, err := set.To[bool]("yes")
+	fmt.Println(b, err != nil)
+
+	// Output: 42 <nil>
+	// false true
+}
+
+func ExampleBind() {
+	type S struct {
+		Str string
+		Num int
+	}
+	slice := make([]S, 2)
+
+	// Rebind only accepts *S so there is no need to worry about a panic.
+	b, _ := set.Bind[S](nil, &slice[0])
+	for k := range slice {
+		_ = b.Rebind(&slice[k])
+		_ = b.Set("Str", fmt.Sprintf("S%v", k))
+		_ = b.Set("Num", k*10)
+	}
+	fmt.Println(slice)
+
+	// TypedField reads a field without type assertions.
+	num, _ := set.TypedField[int](b, "Num")
+	str, _ := set.TypedField[string](b, "Num") // Coerced.
+	fmt.Println(num, str)
+
+	// Output: [{S0 0} {S1 10}]
+	// 10 10
+}
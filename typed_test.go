@@ -0,0 +1,100 @@
+package set_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestTo(t *testing.T) {
+	chk := assert.New(t)
+	//
+	n, err := set.To[int64]("12")
+	chk.NoError(err)
+	chk.Equal(int64(12), n)
+	s, err := set.To[string](3.5)
+	chk.NoError(err)
+	chk.Equal("3.5", s)
+	d, err := set.To[time.Duration]("2s")
+	chk.NoError(err)
+	chk.Equal(2*time.Second, d)
+	slice, err := set.To[[]int]([]string{"1", "2"})
+	chk.NoError(err)
+	chk.Equal([]int{1, 2}, slice)
+	ptr, err := set.To[*int]("7")
+	chk.NoError(err)
+	chk.Equal(7, *ptr)
+	//
+	n, err = set.To[int64]("twelve")
+	chk.Error(err)
+	chk.Equal(int64(0), n)
+}
+
+func TestTypedBoundMapping(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type T struct {
+		Name    string
+		Age     int
+		Address *Address
+	}
+	chk := assert.New(t)
+	var a, b T
+	bound, err := set.Bind[T](nil, &a)
+	chk.NoError(err)
+	chk.NoError(bound.Set("Name", "a"))
+	chk.NoError(bound.Set("Address_City", "Springfield"))
+	chk.ErrorIs(bound.Rebind(nil), set.ErrReadOnly)
+	chk.NoError(bound.Rebind(&b))
+	chk.NoError(bound.Set("Age", "42"))
+	chk.Equal(T{Name: "a", Address: &Address{City: "Springfield"}}, a)
+	chk.Equal(T{Age: 42}, b)
+	//
+	copied := bound.Copy()
+	chk.NoError(copied.Rebind(&a))
+	age, err := set.TypedField[int](bound, "Age")
+	chk.NoError(err)
+	chk.Equal(42, age)
+	name, err := set.TypedField[string](copied, "Name")
+	chk.NoError(err)
+	chk.Equal("a", name)
+	str, err := set.TypedField[string](bound, "Age")
+	chk.NoError(err)
+	chk.Equal("42", str)
+	_, err = set.TypedField[int](bound, "Unknown")
+	chk.ErrorIs(err, set.ErrUnknownField)
+	_, err = set.TypedField[int](copied, "Name")
+	chk.Error(err)
+	//
+	_, err = set.Bind[T](&set.Mapper{}, nil)
+	chk.ErrorIs(err, set.ErrReadOnly)
+}
+
+func TestTypedPreparedMapping(t *testing.T) {
+	type T struct {
+		Name string
+		Age  int
+	}
+	chk := assert.New(t)
+	var a, b T
+	prepared, err := set.Prepare[T](&set.Mapper{}, &a)
+	chk.NoError(err)
+	chk.NoError(prepared.Plan("Age", "Name"))
+	chk.NoError(prepared.Set(1))
+	chk.NoError(prepared.Set("a"))
+	chk.ErrorIs(prepared.Rebind(nil), set.ErrReadOnly)
+	//
+	copied := prepared.Copy()
+	chk.NoError(copied.Rebind(&b))
+	chk.NoError(copied.Set("2"))
+	chk.NoError(copied.Set("b"))
+	fields, err := copied.Fields(nil)
+	chk.NoError(err)
+	chk.Equal([]interface{}{2, "b"}, fields)
+	chk.Equal(T{Name: "a", Age: 1}, a)
+	chk.Equal(T{Name: "b", Age: 2}, b)
+}
@@ -0,0 +1,172 @@
+package set
+
+import (
+	"reflect"
+	"time"
+	"unsafe"
+
+	"github.com/nofeaturesonlybugs/set/path"
+)
+
+// unsafeTypes contains the types accessed with pointer arithmetic when Mapper.UnsafeOffsets is
+// true indexed by their kind; time.Time is checked separately.
+var unsafeTypes = [...]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+	reflect.String:  reflect.TypeOf(""),
+}
+
+// typeTime is the reflect.Type for time.Time.
+var typeTime = reflect.TypeOf(time.Time{})
+
+// unsafeLeaf returns true if the field at the end of offsets can be accessed with pointer
+// arithmetic; it is checked once when the mapping is created.
+func unsafeLeaf(offsets path.PathOffsets) bool {
+	if len(offsets) == 0 {
+		return false
+	}
+	leaf := offsets[len(offsets)-1]
+	if leaf.IndirectionLevel != 0 {
+		return false
+	} else if k := leaf.Type.Kind(); int(k) < len(unsafeTypes) && unsafeTypes[k] == leaf.Type {
+		return true
+	}
+	return leaf.Type == typeTime
+}
+
+// unsafeSet assigns value to the field described by offsets within the struct at base; nil
+// pointers along the way are instantiated.  offsets must have passed unsafeLeaf.  ok is false
+// if the field must be assigned with reflect because value does not have exactly the field's type.
+func unsafeSet(base unsafe.Pointer, offsets path.PathOffsets, value interface{}) (ok bool) {
+	// NB  Each case asserts the concrete type, which is cheaper than comparing reflect.Type.
+	switch offsets[len(offsets)-1].Type.Kind() {
+	case reflect.Bool:
+		var tt bool
+		if tt, ok = value.(bool); ok {
+			*(*bool)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Int:
+		var tt int
+		if tt, ok = value.(int); ok {
+			*(*int)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Int8:
+		var tt int8
+		if tt, ok = value.(int8); ok {
+			*(*int8)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Int16:
+		var tt int16
+		if tt, ok = value.(int16); ok {
+			*(*int16)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Int32:
+		var tt int32
+		if tt, ok = value.(int32); ok {
+			*(*int32)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Int64:
+		var tt int64
+		if tt, ok = value.(int64); ok {
+			*(*int64)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Uint:
+		var tt uint
+		if tt, ok = value.(uint); ok {
+			*(*uint)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Uint8:
+		var tt uint8
+		if tt, ok = value.(uint8); ok {
+			*(*uint8)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Uint16:
+		var tt uint16
+		if tt, ok = value.(uint16); ok {
+			*(*uint16)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Uint32:
+		var tt uint32
+		if tt, ok = value.(uint32); ok {
+			*(*uint32)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Uint64:
+		var tt uint64
+		if tt, ok = value.(uint64); ok {
+			*(*uint64)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Float32:
+		var tt float32
+		if tt, ok = value.(float32); ok {
+			*(*float32)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Float64:
+		var tt float64
+		if tt, ok = value.(float64); ok {
+			*(*float64)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.String:
+		var tt string
+		if tt, ok = value.(string); ok {
+			*(*string)(offsets.Pointer(base, true)) = tt
+		}
+	case reflect.Struct:
+		var tt time.Time
+		if tt, ok = value.(time.Time); ok {
+			*(*time.Time)(offsets.Pointer(base, true)) = tt
+		}
+	}
+	return ok
+}
+
+// unsafeRead returns the value of the field described by offsets within the struct at base;
+// offsets must have passed unsafeLeaf.  When alloc is false nil pointers along the way are not
+// instantiated and the value is nil.
+func unsafeRead(base unsafe.Pointer, offsets path.PathOffsets, alloc bool) interface{} {
+	p := offsets.Pointer(base, alloc)
+	if p == nil {
+		return nil
+	}
+	switch offsets[len(offsets)-1].Type.Kind() {
+	case reflect.Bool:
+		return *(*bool)(p)
+	case reflect.Int:
+		return *(*int)(p)
+	case reflect.Int8:
+		return *(*int8)(p)
+	case reflect.Int16:
+		return *(*int16)(p)
+	case reflect.Int32:
+		return *(*int32)(p)
+	case reflect.Int64:
+		return *(*int64)(p)
+	case reflect.Uint:
+		return *(*uint)(p)
+	case reflect.Uint8:
+		return *(*uint8)(p)
+	case reflect.Uint16:
+		return *(*uint16)(p)
+	case reflect.Uint32:
+		return *(*uint32)(p)
+	case reflect.Uint64:
+		return *(*uint64)(p)
+	case reflect.Float32:
+		return *(*float32)(p)
+	case reflect.Float64:
+		return *(*float64)(p)
+	case reflect.String:
+		return *(*string)(p)
+	}
+	return *(*time.Time)(p)
+}
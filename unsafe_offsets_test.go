@@ -0,0 +1,162 @@
+package set_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestMapper_UnsafeOffsets(t *testing.T) {
+	type Level int
+	type Scalars struct {
+		B   bool
+		I   int
+		I8  int8
+		I16 int16
+		I32 int32
+		I64 int64
+		U   uint
+		U8  uint8
+		U16 uint16
+		U32 uint32
+		U64 uint64
+		F32 float32
+		F64 float64
+		S   string
+		T   time.Time
+	}
+	type inner struct {
+		Promoted string
+	}
+	type Item struct {
+		Name string
+	}
+	type Leaf struct {
+		I int
+		S string
+		T time.Time
+	}
+	type T struct {
+		Scalars
+		inner
+		Level  Level
+		Ptr    *string
+		Nested *struct {
+			Deep **Leaf
+		}
+		Vec   [2]int
+		Items []Item
+	}
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	str := "ptr"
+	values := map[string]interface{}{
+		"B": true, "I": -1, "I8": int8(-8), "I16": int16(-16), "I32": int32(-32), "I64": int64(-64),
+		"U": uint(1), "U8": uint8(8), "U16": uint16(16), "U32": uint32(32), "U64": uint64(64),
+		"F32": float32(3.5), "F64": 6.25, "S": "str", "T": when,
+		"Promoted": "promoted", "Level": Level(3), "Ptr": &str,
+		"Nested_Deep_I": 42, "Nested_Deep_S": "deep", "Nested_Deep_T": when,
+		"Vec_1": 7, "Items_1_Name": "item",
+	}
+	fields := []string{
+		"B", "I", "I8", "I16", "I32", "I64", "U", "U8", "U16", "U32", "U64", "F32", "F64", "S", "T",
+		"Promoted", "Level", "Ptr", "Nested_Deep_I", "Nested_Deep_S", "Nested_Deep_T", "Vec_1", "Items_1_Name",
+	}
+	newMapper := func(unsafe bool) *set.Mapper {
+		return &set.Mapper{
+			Elevated:        set.NewTypeList(Scalars{}),
+			PromoteEmbedded: true,
+			IndexSlices:     true,
+			Join:            "_",
+			UnsafeOffsets:   unsafe,
+		}
+	}
+	safe, unsafe := newMapper(false), newMapper(true)
+	t.Run("bound", func(t *testing.T) {
+		chk := assert.New(t)
+		var expect, actual T
+		for _, v := range []struct {
+			mapper *set.Mapper
+			dest   *T
+		}{{safe, &expect}, {unsafe, &actual}} {
+			b, err := v.mapper.Bind(v.dest)
+			chk.NoError(err)
+			for _, field := range fields {
+				chk.NoError(b.Set(field, values[field]), field)
+			}
+			// Coercion is handled by reflect.
+			chk.NoError(b.Set("I32", "33"))
+		}
+		chk.Equal(expect, actual)
+		chk.Equal(int32(33), actual.I32)
+		chk.Equal("deep", (*actual.Nested.Deep).S)
+		//
+		b, err := unsafe.Bind(&actual)
+		chk.NoError(err)
+		got, err := b.Fields(fields, nil)
+		chk.NoError(err)
+		ro, err := b.ReadFields(fields, nil)
+		chk.NoError(err)
+		b, err = safe.Bind(&expect)
+		chk.NoError(err)
+		want, err := b.Fields(fields, nil)
+		chk.NoError(err)
+		chk.Equal(want, got)
+		chk.Equal(want, ro)
+		chk.IsType(Level(0), got[16])
+		//
+		// Nil pointers are allocated by Fields but not by ReadFields.
+		var empty T
+		b, err = unsafe.Bind(&empty)
+		chk.NoError(err)
+		ro, err = b.ReadFields([]string{"Nested_Deep_I", "S"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{nil, ""}, ro)
+		chk.Nil(empty.Nested)
+		got, err = b.Fields([]string{"Nested_Deep_I"}, nil)
+		chk.NoError(err)
+		chk.Equal([]interface{}{0}, got)
+		chk.NotNil(empty.Nested)
+	})
+	t.Run("prepared", func(t *testing.T) {
+		chk := assert.New(t)
+		var expect, actual T
+		for _, v := range []struct {
+			mapper *set.Mapper
+			dest   *T
+		}{{safe, &expect}, {unsafe, &actual}} {
+			p, err := v.mapper.Prepare(v.dest)
+			chk.NoError(err)
+			chk.NoError(p.Plan(fields...))
+			for _, field := range fields {
+				chk.NoError(p.Set(values[field]), field)
+			}
+		}
+		chk.Equal(expect, actual)
+		//
+		p, err := unsafe.Prepare(&actual)
+		chk.NoError(err)
+		chk.NoError(p.Plan(fields...))
+		got, err := p.Fields(nil)
+		chk.NoError(err)
+		ro, err := p.ReadFields(nil)
+		chk.NoError(err)
+		p, err = safe.Prepare(&expect)
+		chk.NoError(err)
+		chk.NoError(p.Plan(fields...))
+		want, err := p.Fields(nil)
+		chk.NoError(err)
+		chk.Equal(want, got)
+		chk.Equal(want, ro)
+		//
+		// Values that are not addressable are read with reflect.
+		p, err = unsafe.PrepareReadOnly(actual)
+		chk.NoError(err)
+		chk.NoError(p.Plan(fields...))
+		ro, err = p.ReadFields(nil)
+		chk.NoError(err)
+		chk.Equal(want, ro)
+	})
+}
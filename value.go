@@ -1,8 +1,10 @@
 package set
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"github.com/nofeaturesonlybugs/set/coerce"
 )
@@ -39,7 +41,7 @@ func V(arg interface{}) Value {
 	v.WriteValue, v.CanWrite = Writable(V)
 	v.TopValue = V
 
-	if v.IsMap || v.IsSlice {
+	if v.IsMap || v.IsSlice || v.IsArray {
 		v.ElemTypeInfo = TypeCache.StatType(v.ElemType)
 	}
 
@@ -83,7 +85,7 @@ type Value struct {
 	// value.  Generally you should avoid it but it's also present if you really know what you're doing.
 	WriteValue reflect.Value
 
-	// When IsMap or IsSlice are true then ElemTypeInfo is a TypeInfo struct describing the element type.
+	// When IsMap, IsSlice, or IsArray are true then ElemTypeInfo is a TypeInfo struct describing the element type.
 	ElemTypeInfo TypeInfo
 
 	//
@@ -221,90 +223,187 @@ func (v Value) FieldsByTag(key string) []Field {
 // Fill() and FillByTag() have essentially the same complicated logic except where they get the string/key to pass
 // to getter() and how they sub-fill nested structures.  The keyFunc and fillFunc arguments allow them to
 // cascade the appropriate logic into this function.
-func (v Value) fill(getter Getter, fields []Field, keyFunc func(Field) string, fillFunc func(Value, Getter) error) error {
-	var err error
+//
+// path is the Go path of v within the value originally passed to Fill() or FillByTag().  When state is
+// non-nil errors are collected in state instead of returned; see FillAll().
+func (v Value) fill(getter Getter, fields []Field, keyFunc func(Field) string, fillFunc func(Value, Getter, string) error, path string, state *fillState) error {
 	for _, field := range fields {
 		getName := keyFunc(field)
-		switch got := getter.Get(getName).(type) {
+		fieldPath := field.Field.Name
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		got := getter.Get(getName)
+		if err := field.fill(getName, got, fieldPath, fillFunc); err != nil {
+			if state == nil {
+				return err
+			}
+			state.add(&FieldError{Path: fieldPath, Key: getName, Value: got, Type: field.Field.Type, Err: err})
+		}
+	}
+	return nil
+}
 
-		case Getter:
-			// What was returned from the Getter is itself a Getter; therefore we expect field.Value
-			// to be either a struct or []struct that we can sub-fill.
-			if field.Value.IsStruct {
-				if err = fillFunc(field.Value, got); err != nil {
-					return err
-				}
-			} else if field.Value.IsSlice && field.Value.ElemTypeInfo.IsStruct {
-				if err = field.Value.Zero(); err != nil {
-					return err
-				}
+// fill assigns got, the value returned from the Getter for getName, to the field; fieldPath is the Go path
+// of the field and fillFunc sub-fills nested structures.
+func (field Field) fill(getName string, got interface{}, fieldPath string, fillFunc func(Value, Getter, string) error) error {
+	var err error
+	switch got := got.(type) {
+
+	case Getter:
+		// What was returned from the Getter is itself a Getter; therefore we expect field.Value
+		// to be either a struct or []struct that we can sub-fill.
+		if field.Value.IsStruct {
+			if err = fillFunc(field.Value, got, fieldPath); err != nil {
+				return err
+			}
+		} else if field.Value.IsSlice && field.Value.ElemTypeInfo.IsStruct {
+			if err = field.Value.Zero(); err != nil {
+				return err
+			}
+			elem := V(reflect.New(field.Value.ElemTypeInfo.Type))
+			if err = fillFunc(elem, got, fieldPath+"[0]"); err != nil {
+				return err
+			}
+			_ = field.Value.Append(elem.WriteValue.Interface()) // It is impossible for this to return an error here.
+		} else if field.Value.IsMap {
+			if err = field.Value.fillMap(getName, got, fieldPath, fillFunc); err != nil {
+				return err
+			}
+		} else {
+			return pkgerr{Err: ErrUnsupported, CallSite: "Value.fill", Context: fmt.Sprintf("value is Getter but field %v is %v", getName, field.Value.Type)}
+		}
+
+	case []Getter:
+		// What was returned from the Getter is a []Getter; therefore we expect field.Value to
+		// be a []struct or struct that we can sub-fill.
+		if field.Value.IsSlice && field.Value.ElemTypeInfo.IsStruct {
+			// Zero out the existing slice.
+			if err = field.Value.Zero(); err != nil {
+				return err
+			}
+			for k, elemGetter := range got {
 				elem := V(reflect.New(field.Value.ElemTypeInfo.Type))
-				if err = fillFunc(elem, got); err != nil {
+				if err = fillFunc(elem, elemGetter, fieldPath+"["+strconv.Itoa(k)+"]"); err != nil {
 					return err
 				}
 				_ = field.Value.Append(elem.WriteValue.Interface()) // It is impossible for this to return an error here.
-			} else {
-				return pkgerr{Err: ErrUnsupported, CallSite: "Value.fill", Context: fmt.Sprintf("value is Getter but field %v is %v", getName, field.Value.Type)}
 			}
-
-		case []Getter:
-			// What was returned from the Getter is a []Getter; therefore we expect field.Value to
-			// be a []struct or struct that we can sub-fill.
-			if field.Value.IsSlice && field.Value.ElemTypeInfo.IsStruct {
-				// Zero out the existing slice.
-				if err = field.Value.Zero(); err != nil {
+		} else if field.Value.IsStruct {
+			size := len(got)
+			if size > 0 {
+				if err = fillFunc(field.Value, got[size-1], fieldPath); err != nil {
 					return err
 				}
-				for _, elemGetter := range got {
-					elem := V(reflect.New(field.Value.ElemTypeInfo.Type))
-					if err = fillFunc(elem, elemGetter); err != nil {
-						return err
-					}
-					_ = field.Value.Append(elem.WriteValue.Interface()) // It is impossible for this to return an error here.
-				}
-			} else if field.Value.IsStruct {
-				size := len(got)
-				if size > 0 {
-					if err = fillFunc(field.Value, got[size-1]); err != nil {
-						return err
-					}
-				}
-			} else {
-				return pkgerr{Err: ErrUnsupported, CallSite: "Value.fill", Context: fmt.Sprintf("value is []Getter but field %v is %v", getName, field.Value.Type)}
 			}
+		} else {
+			return pkgerr{Err: ErrUnsupported, CallSite: "Value.fill", Context: fmt.Sprintf("value is []Getter but field %v is %v", getName, field.Value.Type)}
+		}
 
+	default:
+		if err = field.Value.To(got); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillMap replaces the map in v with a new map containing every key in getter, which must be a
+// KeyGetter.  Keys and values are coerced with To; values that are Getters are sub-filled with
+// fillFunc when the map element type is a struct or another map.  path is the Go path of v.
+func (v Value) fillMap(name string, getter Getter, path string, fillFunc func(Value, Getter, string) error) error {
+	keyGetter, ok := getter.(KeyGetter)
+	if !ok {
+		return pkgerr{Err: ErrUnsupported, CallSite: "Value.fill", Context: fmt.Sprintf("value for map field %v is Getter but not KeyGetter", name)}
+	}
+	keys := keyGetter.Keys()
+	m := reflect.MakeMapWithSize(v.Type, len(keys))
+	for _, key := range keys {
+		mapKey, elem := V(reflect.New(v.Type.Key())), V(reflect.New(v.ElemType))
+		if err := mapKey.To(key); err != nil {
+			return err
+		}
+		switch got := keyGetter.Get(key).(type) {
+		case Getter:
+			var err error
+			if elem.IsStruct {
+				err = fillFunc(elem, got, path+"["+key+"]")
+			} else if elem.IsMap {
+				err = elem.fillMap(name+"."+key, got, path+"["+key+"]", fillFunc)
+			} else {
+				err = pkgerr{Err: ErrUnsupported, CallSite: "Value.fill", Context: fmt.Sprintf("value is Getter but element %v.%v is %v", name, key, elem.Type)}
+			}
+			if err != nil {
+				return err
+			}
 		default:
-			if err = field.Value.To(got); err != nil {
+			if err := elem.To(got); err != nil {
 				return err
 			}
 		}
+		m.SetMapIndex(reflect.Indirect(mapKey.TopValue), reflect.Indirect(elem.TopValue))
 	}
+	v.WriteValue.Set(m)
 	return nil
 }
 
 // Fill iterates a struct's fields and calls To() on each one by passing the field name to the Getter.
-// Fill stops and returns on the first error encountered.
+// Fill stops and returns on the first error encountered; use FillAll to report every field that
+// can not be filled.
+//
+// Fields that are maps are populated when the Getter returns a KeyGetter for the field, such as the
+// Getter returned by MapGetter for a nested map.  Each key and value is coerced into the map's key
+// and element types; when the element type is a struct or map it is filled from a nested Getter.
 func (v Value) Fill(getter Getter) error {
+	return v.fillByName(getter, "", nil)
+}
+
+// FillAll is the same as Fill() except it does not stop on the first error.  Every field that can not
+// be filled is described by a *FieldError and the returned error wraps a *FillError containing them.
+//
+// Fields of nested structs, including structs within slices and maps, are reported individually with
+// their Go path such as Address.Zip or Items[1].Name.  Map keys and other map elements that can not be
+// coerced are reported for the map field as a whole.
+func (v Value) FillAll(getter Getter) error {
+	state := &fillState{}
+	_ = v.fillByName(getter, "", state)
+	return state.err("Value.FillAll")
+}
+
+// fillByName powers Fill() and FillAll(); see fill() for path and state.
+func (v Value) fillByName(getter Getter, path string, state *fillState) error {
 	fields := v.Fields()
 	keyFunc := func(field Field) string {
 		return field.Field.Name
 	}
-	fillFunc := func(value Value, getter Getter) error {
-		return value.Fill(getter)
+	fillFunc := func(value Value, getter Getter, path string) error {
+		return value.fillByName(getter, path, state)
 	}
-	return v.fill(getter, fields, keyFunc, fillFunc)
+	return v.fill(getter, fields, keyFunc, fillFunc, path, state)
 }
 
 // FillByTag is the same as Fill() except the argument passed to Getter is the value of the struct-tag.
 func (v Value) FillByTag(key string, getter Getter) error {
+	return v.fillByTag(key, getter, "", nil)
+}
+
+// FillByTagAll is the same as FillAll() except the argument passed to Getter is the value of the struct-tag.
+func (v Value) FillByTagAll(key string, getter Getter) error {
+	state := &fillState{}
+	_ = v.fillByTag(key, getter, "", state)
+	return state.err("Value.FillByTagAll")
+}
+
+// fillByTag powers FillByTag() and FillByTagAll(); see fill() for path and state.
+func (v Value) fillByTag(key string, getter Getter, path string, state *fillState) error {
 	fields := v.FieldsByTag(key)
 	keyFunc := func(field Field) string {
 		return field.TagValue
 	}
-	fillFunc := func(value Value, getter Getter) error {
-		return value.FillByTag(key, getter)
+	fillFunc := func(value Value, getter Getter, path string) error {
+		return value.fillByTag(key, getter, path, state)
 	}
-	return v.fill(getter, fields, keyFunc, fillFunc)
+	return v.fill(getter, fields, keyFunc, fillFunc, path, state)
 }
 
 // Rebind will swap the underlying original value used to create Value with the incoming
@@ -393,13 +492,126 @@ func (v Value) NewElem() (Value, error) {
 //		-> Note: T != S; they are now different slices; changes to T do not affect S and vice versa.
 //		-> Note: If the elements themselves are pointers then, for example, T[0] and S[0] point
 //			at the same memory and will see changes to whatever is pointed at.
+//	T is array [N]T, S is scalar
+//		-> T is set to [N]T{ S }; i.e. S is the first element and remaining elements are zero.
+//	T is array [N]T, S is slice []S or array [M]S
+//		-> T is set to [N]T{ S... }; an error wrapping ErrIndexOutOfBounds is returned if len(S) > N.
+//		-> Note: If T is an array of bytes and S is a string then S is treated as []byte(S).
+//	T is map map[K]T, S is map map[J]S and S is not assignable to T
+//		-> T is set to a new map with each key J coerced to K and each S coerced to T.
+//
+// Before any of the above a ConverterFunc registered in the global Converters registry
+// for the pair (S, T) takes precedence.
+//
+// If T is time.Time or time.Duration then S is coerced with coerce.Time or coerce.Duration.
+//
+// If *T implements encoding.TextUnmarshaler and S is not assignable to T then S is coerced
+// to string and passed to UnmarshalText.  When T is a primitive kind this only occurs if S is
+// a string or []byte.
 func (v Value) To(arg interface{}) error {
+	return v.to(arg, nil, false)
+}
+
+// ToStrict is the same as To except assignments that would lose information return an
+// error wrapping ErrLossy instead of truncating, picking the last element of a slice, or
+// zeroing the value.
+//
+// The following are lossy:
+//	nil assigned to anything other than a slice, map, or interface.
+//	S is a slice with a length other than one and T is not a slice.
+//	S is bool and T is a number or vice versa.
+//	S has a fractional part and T is an integer.
+//	S is not a primitive and T is a primitive (unless T is string and S implements
+//	encoding.TextMarshaler or fmt.Stringer).
+//	S can not be assigned to T at all.
+//
+// When an error is returned the wrapped value is set to an appropriate zero type, just as with To.
+// See coerce.CheckStrict for details.
+func (v Value) ToStrict(arg interface{}) error {
+	return v.to(arg, nil, true)
+}
+
+// ToMap is the inverse of Fill; it copies the struct wrapped by Value into a map keyed by field
+// name.  Nested structs become map[string]interface{} and slices of structs become
+// []map[string]interface{}; the result can be passed to MapGetter and then Fill.
+//
+// See Mapper.UnmapNested for more control over the generated keys.
+func (v Value) ToMap() (map[string]interface{}, error) {
+	return v.toMap("Value.ToMap", &Mapper{})
+}
+
+// ToMapByTag is the same as ToMap except only fields with the struct tag key are copied and
+// the tag value is used as the map key; it is the inverse of FillByTag.
+func (v Value) ToMapByTag(key string) (map[string]interface{}, error) {
+	return v.toMap("Value.ToMapByTag", &Mapper{Tags: []string{key}, TaggedFieldsOnly: true})
+}
+
+// toMap is the implementation of ToMap and ToMapByTag.
+func (v Value) toMap(callsite string, mapper *Mapper) (map[string]interface{}, error) {
+	if v.Kind != reflect.Struct {
+		return nil, pkgerr{Err: ErrUnsupported, CallSite: callsite, Context: fmt.Sprintf("expected struct but got %T", v.original)}
+	}
+	// Like Fill there is no limit on depth; only cyclic values are cut short.
+	rv := map[string]interface{}{}
+	mapper.unmap(v.WriteValue, true, "", rv, newUnmapState(-1))
+	return rv, nil
+}
+
+// to is the implementation of To and ToStrict; converters is an optional ConverterRegistry
+// that is consulted before the global Converters registry.
+func (v Value) to(arg interface{}, converters *ConverterRegistry, strict bool) error {
 	if v.err != nil {
 		return v.err.(pkgerr).WithCallSite("Value.To")
 	} else if arg == nil {
+		if strict && !v.IsSlice && !v.IsMap && v.Kind != reflect.Interface {
+			_ = v.Zero()
+			return v.lossy(arg)
+		}
 		return v.Zero()
 	}
 	//
+	if handled, err := convert(v, arg, converters); handled {
+		return err
+	}
+	//
+	// time.Time and time.Duration have dedicated coercions; they are checked before
+	// the kind switch because time.Duration is an int64.
+	switch v.Type {
+	case coerce.TypeTime:
+		if strict {
+			if err := coerce.CheckStrict(arg, reflect.Struct); err != nil {
+				_ = v.Zero()
+				return err
+			}
+		}
+		c, err := coerce.Time(arg)
+		v.WriteValue.Set(reflect.ValueOf(c))
+		return err
+	case coerce.TypeDuration:
+		if strict {
+			if err := coerce.CheckStrict(arg, reflect.Int64); err != nil {
+				_ = v.Zero()
+				return err
+			}
+		}
+		c, err := coerce.Duration(arg)
+		v.WriteValue.SetInt(int64(c))
+		return err
+	}
+	//
+	if v.isTextUnmarshaler {
+		if handled, err := v.unmarshalText(arg, strict); handled {
+			return err
+		}
+	}
+	//
+	if strict && v.IsScalar {
+		if err := coerce.CheckStrict(arg, v.Kind); err != nil {
+			_ = v.Zero()
+			return err
+		}
+	}
+	//
 	// This typeswitch handles the case where we are a scalar.
 	switch v.Kind {
 	case reflect.Bool:
@@ -464,9 +676,13 @@ func (v Value) To(arg interface{}) error {
 	rv := reflect.ValueOf(arg)
 	for {
 		//
-		// If arg is any kind of pointer dereference to final value or nil
-		for ; rv.Kind() == reflect.Ptr; rv = rv.Elem() {
+		// If arg is any kind of pointer or interface dereference to final value or nil
+		for ; rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface; rv = rv.Elem() {
 			if rv.IsNil() {
+				if strict && !v.IsSlice && !v.IsMap && v.Kind != reflect.Interface {
+					_ = v.Zero()
+					return v.lossy(arg)
+				}
 				return v.Zero()
 			}
 		}
@@ -478,29 +694,132 @@ func (v Value) To(arg interface{}) error {
 			return nil
 		}
 		//
-		if v.IsSlice {
+		if v.IsMap && rv.Kind() == reflect.Map {
+			return v.assignMap(rv, converters, strict)
+		}
+		//
+		if v.IsArray {
+			return v.assignArray(rv, converters, strict)
+		} else if v.IsSlice {
 			_ = v.Zero() // Zero only returns errors on nil receiver, invalid kind, or !CanWrite -- which are already checked above.
-			if rv.Kind() != reflect.Slice {
-				arg = []interface{}{arg}
+			slice := rv
+			if K := rv.Kind(); K != reflect.Slice && K != reflect.Array {
+				slice = reflect.ValueOf([]interface{}{rv.Interface()})
 			}
-			slice := reflect.ValueOf(arg)
 			for k, size := 0, slice.Len(); k < size; k++ {
 				elem := V(reflect.New(v.ElemType))
-				if err := elem.To(slice.Index(k).Interface()); err != nil {
+				if err := elem.to(slice.Index(k).Interface(), converters, strict); err != nil {
 					_ = v.Zero()
 					return err
 				}
 				v.WriteValue.Set(reflect.Append(v.WriteValue, elem.WriteValue))
 			}
 			return nil
-		} else if rv.Kind() == reflect.Slice {
-			// When incoming value is a slice we use the last value and try again.
-			if n := rv.Len(); n > 0 {
+		} else if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			// When incoming value is a slice or array we use the last value and try again.
+			if n := rv.Len(); n > 0 && (!strict || n == 1) {
 				rv = rv.Index(n - 1)
 				continue
 			}
-			return v.Zero()
 		}
-		return v.Zero()
+		_ = v.Zero()
+		if strict {
+			return v.lossy(arg)
+		}
+		return nil
+	}
+}
+
+// assignArray sets v, which must be an array, from rv.  If rv is a slice or array its elements
+// are coerced into the elements of v; otherwise rv is coerced into the first element.  Remaining
+// elements are zero.  It is an error if rv has more elements than v.
+//
+// As a special case a string assigned to an array of bytes is treated as []byte.
+func (v Value) assignArray(rv reflect.Value, converters *ConverterRegistry, strict bool) error {
+	if rv.Kind() == reflect.String && v.ElemType.Kind() == reflect.Uint8 {
+		rv = reflect.ValueOf([]byte(rv.String()))
+	} else if K := rv.Kind(); K != reflect.Slice && K != reflect.Array {
+		rv = reflect.ValueOf([]interface{}{rv.Interface()})
+	}
+	if size, max := rv.Len(), v.Type.Len(); size > max {
+		_ = v.Zero()
+		return pkgerr{Err: ErrIndexOutOfBounds, CallSite: "Value.To", Context: fmt.Sprintf("%v elements exceed %v", size, v.Type)}
+	}
+	array := reflect.New(v.Type).Elem()
+	for k, size := 0, rv.Len(); k < size; k++ {
+		elem := V(array.Index(k).Addr())
+		if err := elem.to(rv.Index(k).Interface(), converters, strict); err != nil {
+			_ = v.Zero()
+			return err
+		}
+	}
+	v.WriteValue.Set(array)
+	return nil
+}
+
+// assignMap sets v, which must be a map, to a new map with the keys and values in the map rv
+// coerced to the key and element types of v.
+func (v Value) assignMap(rv reflect.Value, converters *ConverterRegistry, strict bool) error {
+	m := reflect.MakeMapWithSize(v.Type, rv.Len())
+	for iter := rv.MapRange(); iter.Next(); {
+		key, elem := V(reflect.New(v.Type.Key())), V(reflect.New(v.ElemType))
+		if err := key.to(iter.Key().Interface(), converters, strict); err != nil {
+			_ = v.Zero()
+			return err
+		} else if err = elem.to(iter.Value().Interface(), converters, strict); err != nil {
+			_ = v.Zero()
+			return err
+		}
+		m.SetMapIndex(reflect.Indirect(key.TopValue), reflect.Indirect(elem.TopValue))
+	}
+	v.WriteValue.Set(m)
+	return nil
+}
+
+// lossy returns the error for ToStrict when arg can not be assigned to v without losing information.
+func (v Value) lossy(arg interface{}) error {
+	return pkgerr{Err: ErrLossy, CallSite: "Value.ToStrict", Context: fmt.Sprintf("can not assign %T to %v", arg, v.Type)}
+}
+
+// unmarshalText attempts to assign arg into v with encoding.TextUnmarshaler; v must be writable and
+// a pointer to its type must implement encoding.TextUnmarshaler.
+//
+// handled is false if arg should instead be assigned by the regular logic in To.
+func (v Value) unmarshalText(arg interface{}, strict bool) (handled bool, err error) {
+	rv := reflect.ValueOf(arg)
+	for ; rv.Kind() == reflect.Ptr; rv = rv.Elem() {
+		if rv.IsNil() {
+			return false, nil
+		}
+	}
+	T, K := rv.Type(), rv.Kind()
+	isBytes := K == reflect.Slice && T.Elem().Kind() == reflect.Uint8
+	if T.AssignableTo(v.Type) {
+		return false, nil
+	} else if v.IsScalar && K != reflect.String && !isBytes {
+		return false, nil
+	}
+	//
+	var text []byte
+	if isBytes {
+		text = rv.Bytes()
+	} else {
+		var s string
+		if strict {
+			if err = coerce.CheckStrict(rv.Interface(), reflect.String); err != nil {
+				_ = v.Zero()
+				return true, err
+			}
+		}
+		if s, err = coerce.String(rv.Interface()); err != nil {
+			_ = v.Zero()
+			return true, err
+		}
+		text = []byte(s)
+	}
+	if err = v.WriteValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+		_ = v.Zero()
+		return true, pkgerr{Err: err, CallSite: "Value.To", Context: "UnmarshalText into " + v.Type.String()}
 	}
+	return true, nil
 }
@@ -1,6 +1,7 @@
 package set_test
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -159,6 +160,39 @@ func ExampleValue_To_sliceToSlice() {
 	// m [2 -4 6 8] n [2 4 6 8]
 }
 
+func ExampleValue_FillAll() {
+	// FillAll fills every field it can and reports the fields it could not fill
+	// together in a FillError.
+	type Address struct {
+		City string
+		Zip  int
+	}
+	type Person struct {
+		Name    string
+		Age     int
+		Address Address
+	}
+	getter := set.MapGetter(map[string]interface{}{
+		"Name":    "Bob",
+		"Age":     "old",
+		"Address": map[string]interface{}{"City": "Springfield", "Zip": "none"},
+	})
+
+	var person Person
+	err := set.V(&person).FillAll(getter)
+	var fillErr *set.FillError
+	if errors.As(err, &fillErr) {
+		for _, fieldErr := range fillErr.Errors {
+			fmt.Printf("%v %v %q\n", fieldErr.Path, fieldErr.Type, fieldErr.Value)
+		}
+	}
+	fmt.Println(person.Name, person.Address.City)
+
+	// Output: Age int "old"
+	// Address.Zip int "none"
+	// Bob Springfield
+}
+
 func ExampleValue_Rebind() {
 	// Once a Value has been created you can swap out the value it mutates
 	// by calling Rebind.  This yields better performance in tight loops where
@@ -1,14 +1,20 @@
 package set_test
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"net"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/nofeaturesonlybugs/set"
+	"github.com/nofeaturesonlybugs/set/coerce"
 )
 
 func TestValueOnNil(t *testing.T) {
@@ -277,6 +283,568 @@ func TestValueTo_UintToUint(t *testing.T) {
 	chk.Equal(uint8(0), U8)
 }
 
+// Level is a type implementing encoding.TextUnmarshaler.
+type Level int
+
+func (l *Level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	case "warn":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %v", string(text))
+	}
+	return nil
+}
+
+func TestValueTo_TextUnmarshaler(t *testing.T) {
+	t.Run("primitive kind", func(t *testing.T) {
+		chk := assert.New(t)
+		var l Level
+		chk.NoError(set.V(&l).To("WARN"))
+		chk.Equal(Level(2), l)
+		chk.NoError(set.V(&l).To([]byte("info")))
+		chk.Equal(Level(1), l)
+		// Non-string sources use regular coercion.
+		chk.NoError(set.V(&l).To(2))
+		chk.Equal(Level(2), l)
+		chk.NoError(set.V(&l).To(Level(0)))
+		chk.Equal(Level(0), l)
+		// Errors from UnmarshalText.
+		l = 2
+		chk.Error(set.V(&l).To("nope"))
+		chk.Equal(Level(0), l)
+	})
+	t.Run("net.IP", func(t *testing.T) {
+		chk := assert.New(t)
+		var ip net.IP
+		chk.NoError(set.V(&ip).To("192.168.1.10"))
+		chk.Equal("192.168.1.10", ip.String())
+		var ips []net.IP
+		chk.NoError(set.V(&ips).To([]string{"10.0.0.1", "::1"}))
+		chk.Equal([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("::1")}, ips)
+		chk.Error(set.V(&ip).To("not an ip"))
+		chk.Nil(ip)
+	})
+	t.Run("big.Int", func(t *testing.T) {
+		chk := assert.New(t)
+		var b big.Int
+		chk.NoError(set.V(&b).To("123456789012345678901234567890"))
+		chk.Equal("123456789012345678901234567890", b.String())
+		var pb *big.Int
+		chk.NoError(set.V(&pb).To(int64(42)))
+		chk.Equal("42", pb.String())
+		// Unsupported source for coerce.String
+		chk.Error(set.V(&b).To(map[string]int{}))
+	})
+	t.Run("struct fields", func(t *testing.T) {
+		chk := assert.New(t)
+		type T struct {
+			Level Level
+			Addr  *net.IP
+		}
+		var dst T
+		err := set.V(&dst).Fill(set.MapGetter(map[string]interface{}{
+			"Level": "info",
+			"Addr":  "127.0.0.1",
+		}))
+		chk.NoError(err)
+		chk.Equal(Level(1), dst.Level)
+		chk.Equal("127.0.0.1", dst.Addr.String())
+	})
+}
+
+func TestValueTo_Time(t *testing.T) {
+	date := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	t.Run("value", func(t *testing.T) {
+		chk := assert.New(t)
+		var tm time.Time
+		chk.NoError(set.V(&tm).To("2021-03-04T05:06:07Z"))
+		chk.Equal(date, tm)
+		chk.NoError(set.V(&tm).To(date.Unix()))
+		chk.True(date.Equal(tm))
+		var ptm *time.Time
+		chk.NoError(set.V(&ptm).To("2021-03-04 05:06:07"))
+		chk.Equal(date, *ptm)
+		chk.Error(set.V(&tm).To("yesterday"))
+		chk.True(tm.IsZero())
+		//
+		var d time.Duration
+		chk.NoError(set.V(&d).To("1h30m"))
+		chk.Equal(90*time.Minute, d)
+		chk.NoError(set.V(&d).To(int64(time.Second)))
+		chk.Equal(time.Second, d)
+		var ds []time.Duration
+		chk.NoError(set.V(&ds).To([]string{"1s", "2m"}))
+		chk.Equal([]time.Duration{time.Second, 2 * time.Minute}, ds)
+		chk.Error(set.V(&d).To("forever"))
+		chk.Equal(time.Duration(0), d)
+	})
+	t.Run("mapper", func(t *testing.T) {
+		chk := assert.New(t)
+		type T struct {
+			CreatedAt time.Time
+			Timeout   time.Duration
+		}
+		var dst T
+		b, err := set.DefaultMapper.Bind(&dst)
+		chk.NoError(err)
+		chk.NoError(b.Set("CreatedAt", "2021-03-04T05:06:07Z"))
+		chk.NoError(b.Set("Timeout", "1h30m"))
+		chk.Equal(date, dst.CreatedAt)
+		chk.Equal(90*time.Minute, dst.Timeout)
+	})
+	t.Run("to string", func(t *testing.T) {
+		// Sources implementing fmt.Stringer are assigned to strings with their String method
+		// and not their underlying number.
+		chk := assert.New(t)
+		type T struct {
+			Timeout string
+			Day     string
+		}
+		var dst T
+		b, err := set.DefaultMapper.Bind(&dst)
+		chk.NoError(err)
+		chk.NoError(b.Set("Timeout", time.Hour))
+		chk.NoError(b.Set("Day", time.Monday))
+		chk.Equal(T{Timeout: "1h0m0s", Day: "Monday"}, dst)
+		var s string
+		chk.NoError(set.V(&s).To(90 * time.Second))
+		chk.Equal("1m30s", s)
+	})
+}
+
+func TestValueToStrict(t *testing.T) {
+	t.Run("scalars", func(t *testing.T) {
+		chk := assert.New(t)
+		var i int
+		chk.NoError(set.V(&i).ToStrict(3.0))
+		chk.Equal(3, i)
+		chk.NoError(set.V(&i).ToStrict([]string{"42"}))
+		chk.Equal(42, i)
+		chk.ErrorIs(set.V(&i).ToStrict(3.9), set.ErrLossy)
+		chk.Equal(0, i)
+		i = 5
+		chk.ErrorIs(set.V(&i).ToStrict("3.9"), set.ErrLossy)
+		chk.Equal(0, i)
+		chk.ErrorIs(set.V(&i).ToStrict([]int{1, 2}), set.ErrLossy)
+		chk.ErrorIs(set.V(&i).ToStrict(true), set.ErrLossy)
+		chk.ErrorIs(set.V(&i).ToStrict(nil), set.ErrLossy)
+		chk.ErrorIs(set.V(&i).ToStrict((*int)(nil)), set.ErrLossy)
+		chk.ErrorIs(set.V(&i).ToStrict(map[string]int{}), set.ErrLossy)
+		// Overflow is reported as before.
+		var i8 int8
+		chk.ErrorIs(set.V(&i8).ToStrict(1000), coerce.ErrOverflow)
+		//
+		var b bool
+		chk.NoError(set.V(&b).ToStrict("true"))
+		chk.True(b)
+		chk.ErrorIs(set.V(&b).ToStrict(1), set.ErrLossy)
+		chk.False(b)
+		//
+		var s string
+		chk.NoError(set.V(&s).ToStrict(3.9))
+		chk.Equal("3.9", s)
+		chk.ErrorIs(set.V(&s).ToStrict([]string{}), set.ErrLossy)
+		// The permissive counterparts.
+		chk.NoError(set.V(&i).To(3.9))
+		chk.Equal(3, i)
+		chk.NoError(set.V(&i).To([]int{1, 2}))
+		chk.Equal(2, i)
+	})
+	t.Run("slices", func(t *testing.T) {
+		chk := assert.New(t)
+		var ints []int
+		chk.NoError(set.V(&ints).ToStrict([]string{"1", "2"}))
+		chk.Equal([]int{1, 2}, ints)
+		chk.NoError(set.V(&ints).ToStrict(nil))
+		chk.Nil(ints)
+		chk.ErrorIs(set.V(&ints).ToStrict([]float64{1, 2.5}), set.ErrLossy)
+		chk.Nil(ints)
+	})
+	t.Run("structs", func(t *testing.T) {
+		type A struct{ N int }
+		type B struct{ N int }
+		chk := assert.New(t)
+		a := A{N: 1}
+		chk.NoError(set.V(&a).ToStrict(A{N: 2}))
+		chk.Equal(2, a.N)
+		chk.NoError(set.V(&a).ToStrict([]A{{N: 3}}))
+		chk.Equal(3, a.N)
+		chk.ErrorIs(set.V(&a).ToStrict(B{N: 4}), set.ErrLossy)
+		chk.Equal(0, a.N)
+		a.N = 5
+		chk.ErrorIs(set.V(&a).ToStrict([]A{{N: 6}, {N: 7}}), set.ErrLossy)
+		chk.Equal(0, a.N)
+		chk.ErrorIs(set.V(&a).ToStrict((*A)(nil)), set.ErrLossy)
+		// The permissive counterpart zeroes without error.
+		a.N = 5
+		chk.NoError(set.V(&a).To(B{N: 4}))
+		chk.Equal(0, a.N)
+	})
+	t.Run("time and text", func(t *testing.T) {
+		chk := assert.New(t)
+		var d time.Duration
+		chk.NoError(set.V(&d).ToStrict("1h"))
+		chk.Equal(time.Hour, d)
+		chk.ErrorIs(set.V(&d).ToStrict(1.5), set.ErrLossy)
+		var tm time.Time
+		chk.NoError(set.V(&tm).ToStrict("2021-03-04"))
+		chk.ErrorIs(set.V(&tm).ToStrict([]string{"2021-03-04", "2021-03-05"}), set.ErrLossy)
+		chk.True(tm.IsZero())
+		//
+		var l Level
+		chk.NoError(set.V(&l).ToStrict("warn"))
+		chk.Equal(Level(2), l)
+		chk.ErrorIs(set.V(&l).ToStrict(1.5), set.ErrLossy)
+		var bi big.Int
+		chk.NoError(set.V(&bi).ToStrict([]string{"12"}))
+		chk.Equal("12", bi.String())
+		chk.ErrorIs(set.V(&bi).ToStrict([]string{"12", "13"}), set.ErrLossy)
+	})
+}
+
+func TestValue_ToMap(t *testing.T) {
+	type Address struct {
+		City string `cfg:"city"`
+		Zip  string
+	}
+	type Config struct {
+		Name      string            `cfg:"name"`
+		Port      int               `cfg:"port"`
+		Hosts     []string          `cfg:"hosts"`
+		Address   Address           `cfg:"address"`
+		Addresses []Address         `cfg:"addresses"`
+		Extra     map[string]string `cfg:"extra"`
+		Untagged  string
+	}
+	config := Config{
+		Name:      "svc",
+		Port:      8080,
+		Hosts:     []string{"a", "b"},
+		Address:   Address{City: "X", Zip: "1"},
+		Addresses: []Address{{City: "Y"}, {City: "Z"}},
+		Extra:     map[string]string{"k": "v"},
+		Untagged:  "untagged",
+	}
+	t.Run("by name", func(t *testing.T) {
+		chk := assert.New(t)
+		m, err := set.V(config).ToMap()
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{
+			"Name":      "svc",
+			"Port":      8080,
+			"Hosts":     []string{"a", "b"},
+			"Address":   map[string]interface{}{"City": "X", "Zip": "1"},
+			"Addresses": []map[string]interface{}{{"City": "Y", "Zip": ""}, {"City": "Z", "Zip": ""}},
+			"Extra":     map[string]string{"k": "v"},
+			"Untagged":  "untagged",
+		}, m)
+		//
+		var dst Config
+		chk.NoError(set.V(&dst).Fill(set.MapGetter(m)))
+		chk.Equal(config, dst)
+	})
+	t.Run("by tag", func(t *testing.T) {
+		chk := assert.New(t)
+		m, err := set.V(&config).ToMapByTag("cfg")
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{
+			"name":      "svc",
+			"port":      8080,
+			"hosts":     []string{"a", "b"},
+			"address":   map[string]interface{}{"city": "X"},
+			"addresses": []map[string]interface{}{{"city": "Y"}, {"city": "Z"}},
+			"extra":     map[string]string{"k": "v"},
+		}, m)
+		//
+		var dst Config
+		chk.NoError(set.V(&dst).FillByTag("cfg", set.MapGetter(m)))
+		expect := config
+		expect.Untagged, expect.Address.Zip = "", ""
+		chk.Equal(expect, dst)
+	})
+	t.Run("cycles", func(t *testing.T) {
+		type Node struct {
+			Name string
+			Next *Node
+		}
+		chk := assert.New(t)
+		a := &Node{Name: "a"}
+		a.Next = &Node{Name: "b", Next: &Node{Name: "c", Next: a}}
+		m, err := set.V(a).ToMap()
+		chk.NoError(err)
+		chk.Equal(map[string]interface{}{
+			"Name": "a",
+			"Next": map[string]interface{}{
+				"Name": "b",
+				"Next": map[string]interface{}{"Name": "c"},
+			},
+		}, m)
+	})
+	t.Run("errors", func(t *testing.T) {
+		chk := assert.New(t)
+		var i int
+		_, err := set.V(&i).ToMap()
+		chk.ErrorIs(err, set.ErrUnsupported)
+		_, err = set.V(nil).ToMapByTag("cfg")
+		chk.ErrorIs(err, set.ErrUnsupported)
+	})
+}
+
+func TestValueTo_Array(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var a [3]int
+	chk.NoError(set.V(&a).To([]string{"1", "2"}))
+	chk.Equal([3]int{1, 2, 0}, a)
+	chk.NoError(set.V(&a).To(9))
+	chk.Equal([3]int{9, 0, 0}, a)
+	chk.NoError(set.V(&a).To([2]float32{4, 5}))
+	chk.Equal([3]int{4, 5, 0}, a)
+	chk.NoError(set.V(&a).To(&[]int{6}))
+	chk.Equal([3]int{6, 0, 0}, a)
+	err := set.V(&a).To([]int{1, 2, 3, 4})
+	chk.ErrorIs(err, set.ErrIndexOutOfBounds)
+	chk.Equal([3]int{}, a)
+	//
+	var b [4]byte
+	chk.NoError(set.V(&b).To("abc"))
+	chk.Equal([4]byte{'a', 'b', 'c', 0}, b)
+	err = set.V(&b).To([]int{1, 256})
+	chk.ErrorIs(err, coerce.ErrOverflow)
+	//
+	var pa *[2]string
+	chk.NoError(set.V(&pa).To([]int{1, 2}))
+	chk.Equal(&[2]string{"1", "2"}, pa)
+	//
+	var s []int
+	chk.NoError(set.V(&s).To([2]string{"7", "8"}))
+	chk.Equal([]int{7, 8}, s)
+	var i int
+	chk.NoError(set.V(&i).To([2]string{"7", "8"}))
+	chk.Equal(8, i)
+	//
+	err = set.V(&a).ToStrict([]int{1, 2})
+	chk.NoError(err)
+	err = set.V(&a).ToStrict([]float64{1.5})
+	chk.ErrorIs(err, set.ErrLossy)
+}
+
+func TestValueTo_Map(t *testing.T) {
+	t.Run("coerce values", func(t *testing.T) {
+		chk := assert.New(t)
+		var m map[string]int
+		chk.NoError(set.V(&m).To(map[string]interface{}{"a": 1, "b": "2", "c": 3.0}))
+		chk.Equal(map[string]int{"a": 1, "b": 2, "c": 3}, m)
+		chk.NoError(set.V(&m).To(&map[string]string{"d": "4"}))
+		chk.Equal(map[string]int{"d": 4}, m)
+		chk.NoError(set.V(&m).To([]map[string]int8{{"x": 1}, {"y": 2}}))
+		chk.Equal(map[string]int{"y": 2}, m)
+		chk.NoError(set.V(&m).To(nil))
+		chk.Nil(m)
+	})
+	t.Run("coerce keys", func(t *testing.T) {
+		chk := assert.New(t)
+		var m map[int]*float64
+		chk.NoError(set.V(&m).To(map[string]string{"1": "1.5", "2": "2.5"}))
+		chk.Len(m, 2)
+		chk.Equal(1.5, *m[1])
+		chk.Equal(2.5, *m[2])
+	})
+	t.Run("nested", func(t *testing.T) {
+		chk := assert.New(t)
+		var m map[string][]int
+		chk.NoError(set.V(&m).To(map[string]interface{}{"a": []string{"1", "2"}, "b": 3}))
+		chk.Equal(map[string][]int{"a": {1, 2}, "b": {3}}, m)
+	})
+	t.Run("assignable", func(t *testing.T) {
+		chk := assert.New(t)
+		src := map[string]int{"a": 1}
+		var m map[string]int
+		chk.NoError(set.V(&m).To(src))
+		chk.Equal(src, m)
+	})
+	t.Run("errors", func(t *testing.T) {
+		chk := assert.New(t)
+		m := map[string]int{"a": 1}
+		chk.Error(set.V(&m).To(map[string]interface{}{"a": "abc"}))
+		chk.Nil(m)
+		var mi map[int]string
+		chk.Error(set.V(&mi).To(map[string]string{"abc": "x"}))
+		chk.Nil(mi)
+		chk.ErrorIs(set.V(&m).ToStrict(map[string]float64{"a": 1.5}), set.ErrLossy)
+		chk.ErrorIs(set.V(&mi).ToStrict(map[float64]string{1.5: "a"}), set.ErrLossy)
+		// Non-map sources are not assignable.
+		m = map[string]int{"a": 1}
+		chk.NoError(set.V(&m).To(42))
+		chk.Nil(m)
+	})
+}
+
+func TestValue_Fill_Map(t *testing.T) {
+	type Attr struct {
+		Value string `json:"value"`
+		Score int    `json:"score"`
+	}
+	type Bag struct {
+		Labels map[string]string       `json:"labels"`
+		Counts map[string]int          `json:"counts"`
+		Attrs  map[string]Attr         `json:"attrs"`
+		PAttrs map[string]*Attr        `json:"pattrs"`
+		Deep   map[string]map[int]bool `json:"deep"`
+	}
+	data := map[string]interface{}{
+		"labels": map[string]interface{}{"env": "prod", "tier": 1},
+		"counts": map[string]string{"a": "1", "b": "2"},
+		"attrs": map[string]interface{}{
+			"color": map[string]interface{}{"value": "red", "score": "5"},
+		},
+		"pattrs": map[string]interface{}{
+			"size": map[string]interface{}{"value": "xl"},
+		},
+		"deep": map[string]interface{}{
+			"x": map[string]interface{}{"1": "true", "2": 0},
+		},
+	}
+	t.Run("fill by tag", func(t *testing.T) {
+		chk := assert.New(t)
+		bag := Bag{Labels: map[string]string{"old": "value"}}
+		chk.NoError(set.V(&bag).FillByTag("json", set.MapGetter(data)))
+		chk.Equal(map[string]string{"env": "prod", "tier": "1"}, bag.Labels)
+		chk.Equal(map[string]int{"a": 1, "b": 2}, bag.Counts)
+		chk.Equal(map[string]Attr{"color": {Value: "red", Score: 5}}, bag.Attrs)
+		chk.Equal(map[string]*Attr{"size": {Value: "xl"}}, bag.PAttrs)
+		chk.Equal(map[string]map[int]bool{"x": {1: true, 2: false}}, bag.Deep)
+	})
+	t.Run("fill", func(t *testing.T) {
+		chk := assert.New(t)
+		var bag Bag
+		chk.NoError(set.V(&bag).Fill(set.MapGetter(map[string]interface{}{
+			"Labels": map[string]interface{}{"env": "dev"},
+			"Attrs": map[string]interface{}{
+				"color": map[string]interface{}{"Value": "blue"},
+			},
+		})))
+		chk.Equal(map[string]string{"env": "dev"}, bag.Labels)
+		chk.Equal(map[string]Attr{"color": {Value: "blue"}}, bag.Attrs)
+	})
+	t.Run("errors", func(t *testing.T) {
+		chk := assert.New(t)
+		var bag Bag
+		// A Getter that can not list its keys.
+		getter := set.GetterFunc(func(name string) interface{} {
+			if name == "Labels" {
+				return set.GetterFunc(func(string) interface{} { return nil })
+			}
+			return nil
+		})
+		chk.ErrorIs(set.V(&bag).Fill(getter), set.ErrUnsupported)
+		chk.Error(set.V(&bag).Fill(set.MapGetter(map[string]interface{}{
+			"Counts": map[string]interface{}{"a": "abc"},
+		})))
+		chk.ErrorIs(set.V(&bag).Fill(set.MapGetter(map[string]interface{}{
+			"Counts": map[string]interface{}{"a": map[string]int{}},
+		})), set.ErrUnsupported)
+		chk.Error(set.V(&bag).Fill(set.MapGetter(map[string]interface{}{
+			"Deep": map[string]interface{}{"x": map[string]interface{}{"abc": true}},
+		})))
+		chk.Error(set.V(&bag).Fill(set.MapGetter(map[string]interface{}{
+			"Attrs": map[string]interface{}{"x": map[string]interface{}{"Score": "abc"}},
+		})))
+		var keyed struct {
+			M map[int]string
+		}
+		chk.Error(set.V(&keyed).Fill(set.MapGetter(map[string]interface{}{
+			"M": map[string]interface{}{"abc": "x"},
+		})))
+	})
+}
+
+func TestValue_FillAll(t *testing.T) {
+	type Item struct {
+		Qty int `json:"qty"`
+	}
+	type Address struct {
+		City string `json:"city"`
+		Zip  int    `json:"zip"`
+	}
+	type T struct {
+		Name    string         `json:"name"`
+		Age     int            `json:"age"`
+		Address *Address       `json:"address"`
+		Items   []Item         `json:"items"`
+		Tags    map[string]int `json:"tags"`
+		Score   float64        `json:"score"`
+	}
+	data := func(keys func(string) string) set.Getter {
+		return set.MapGetter(map[string]interface{}{
+			keys("Name"):    "Bob",
+			keys("Age"):     "abc",
+			keys("Address"): map[string]interface{}{keys("City"): "Springfield", keys("Zip"): "xyz"},
+			keys("Items"):   []map[string]interface{}{{keys("Qty"): 1}, {keys("Qty"): "many"}},
+			keys("Tags"):    map[string]interface{}{"a": "bad"},
+			keys("Score"):   2.5,
+		})
+	}
+	check := func(t *testing.T, err error, keys []string) {
+		chk := assert.New(t)
+		chk.ErrorIs(err, coerce.ErrInvalid)
+		var fillErr *set.FillError
+		chk.True(errors.As(err, &fillErr))
+		var paths, gotKeys []string
+		for _, fieldErr := range fillErr.Errors {
+			paths = append(paths, fieldErr.Path)
+			gotKeys = append(gotKeys, fieldErr.Key)
+		}
+		chk.Equal([]string{"Age", "Address.Zip", "Items[1].Qty", "Tags"}, paths)
+		chk.Equal(keys, gotKeys)
+		var fieldErr *set.FieldError
+		chk.True(errors.As(err, &fieldErr))
+		chk.Equal("Age", fieldErr.Path)
+		chk.Equal("abc", fieldErr.Value)
+		chk.Equal(reflect.TypeOf(0), fieldErr.Type)
+		chk.ErrorIs(fieldErr, coerce.ErrInvalid)
+		chk.Equal(reflect.TypeOf(0), fillErr.Errors[1].Type)
+		chk.Equal(reflect.TypeOf(map[string]int{}), fillErr.Errors[3].Type)
+		chk.Contains(err.Error(), "field [Items[1].Qty]")
+	}
+	t.Run("fill", func(t *testing.T) {
+		chk := assert.New(t)
+		var dest T
+		err := set.V(&dest).FillAll(data(func(s string) string { return s }))
+		check(t, err, []string{"Age", "Zip", "Qty", "Tags"})
+		chk.Equal("Bob", dest.Name)
+		chk.Equal("Springfield", dest.Address.City)
+		chk.Equal(2.5, dest.Score)
+		chk.Len(dest.Items, 2)
+		//
+		// Fill stops on the first error.
+		err = set.V(&dest).Fill(data(func(s string) string { return s }))
+		chk.ErrorIs(err, coerce.ErrInvalid)
+		var fillErr *set.FillError
+		chk.False(errors.As(err, &fillErr))
+	})
+	t.Run("fill by tag", func(t *testing.T) {
+		chk := assert.New(t)
+		var dest T
+		err := set.V(&dest).FillByTagAll("json", data(strings.ToLower))
+		check(t, err, []string{"age", "zip", "qty", "tags"})
+		chk.Equal("Bob", dest.Name)
+	})
+	t.Run("no errors", func(t *testing.T) {
+		chk := assert.New(t)
+		var dest T
+		chk.NoError(set.V(&dest).FillAll(set.MapGetter(map[string]interface{}{"Age": 42})))
+		chk.Equal(42, dest.Age)
+		chk.NoError(set.V(&dest).FillByTagAll("json", set.MapGetter(map[string]interface{}{"age": 43})))
+		chk.Equal(43, dest.Age)
+	})
+}
+
 func TestValueToFast(t *testing.T) {
 	chk := assert.New(t)
 	var (